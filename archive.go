@@ -0,0 +1,262 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ArchiveFormat validates a download format, defaulting to tar.
+func ArchiveFormat(format string) (string, error) {
+	switch format {
+	case "":
+		return "tar", nil
+	case "tar", "zip", "raw":
+		return format, nil
+	}
+	return "", &FieldError{"format", "format must be tar, zip or raw"}
+}
+
+// WritePathDownload sends the tar stream of a CopyFromContainer call as
+// tar or zip, or for "raw" the single regular file it contains as-is.
+func WritePathDownload(w http.ResponseWriter, src io.Reader, stat container.PathStat, name, format string) {
+	if format != "raw" {
+		WriteArchive(w, src, name, format)
+		return
+	}
+	if !stat.Mode.IsRegular() {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "raw format needs a regular file", Field: "format"})
+		return
+	}
+	tr := tar.NewReader(src)
+	if _, err := tr.Next(); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename=%q`, name))
+	w.Header().Set("Content-Length", strconv.FormatInt(stat.Size, 10))
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, tr)
+}
+
+// WriteArchive sends a tar stream to the client as a download, converting
+// it to zip when format is "zip". name is used for the file name.
+func WriteArchive(w http.ResponseWriter, src io.Reader, name, format string) {
+	if name == "" || name == "/" || name == "." {
+		name = "archive"
+	}
+	if format == "zip" {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename=%q`, name+".zip"))
+		w.WriteHeader(http.StatusOK)
+		_ = TarToZip(w, src)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename=%q`, name+".tar"))
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, src)
+}
+
+// TarToZip re-encodes a tar stream as a zip archive, keeping names, modes,
+// mtimes and symlinks.
+func TarToZip(dst io.Writer, src io.Reader) error {
+	zw := zip.NewWriter(dst)
+	tr := tar.NewReader(src)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		fh := &zip.FileHeader{Name: strings.TrimPrefix(hdr.Name, "/"), Modified: hdr.ModTime, Method: zip.Deflate}
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			fh.Name = strings.TrimSuffix(fh.Name, "/") + "/"
+			fh.Method = zip.Store
+			fh.SetMode(mode | os.ModeDir)
+		case tar.TypeSymlink:
+			fh.SetMode(mode | os.ModeSymlink)
+		case tar.TypeReg:
+			fh.SetMode(mode)
+		default:
+			continue // 장치 파일 등은 zip으로 표현할 수 없음
+		}
+		fw, err := zw.CreateHeader(fh)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			_, err = io.WriteString(fw, hdr.Linkname)
+		case tar.TypeReg:
+			_, err = io.Copy(fw, tr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// uploadFile is one file of an upload request.
+type uploadFile struct {
+	name string
+	size int64
+	open func() (io.ReadCloser, error)
+}
+
+// CopyUpload turns an upload request into a tar stream and passes it to
+// copyTo along with the directory to extract it in. The body may be
+//   - multipart/form-data: every file part is stored in dir (created if missing)
+//   - application/x-tar: extracted as-is into the existing directory dir
+//   - anything else: a single file named by ?name= or the X-File-Name header,
+//     stored in dir (created if missing)
+//
+// uid and gid query parameters set the owner of the stored files. It returns
+// the paths written, or nil for a tar body. Problems with the request itself
+// are *FieldError.
+func CopyUpload(r *http.Request, dir string, copyTo func(dst string, content io.Reader) error) ([]string, error) {
+	ct := r.Header.Get("Content-Type")
+	if strings.HasPrefix(ct, "application/x-tar") || strings.HasPrefix(ct, "application/tar") {
+		return nil, copyTo(dir, r.Body)
+	}
+
+	var files []uploadFile
+	if strings.HasPrefix(ct, "multipart/form-data") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, &FieldError{"file", "invalid multipart body: " + err.Error()}
+		}
+		defer r.MultipartForm.RemoveAll()
+		for _, fhs := range r.MultipartForm.File {
+			for _, fh := range fhs {
+				files = append(files, uploadFile{
+					name: fh.Filename,
+					size: fh.Size,
+					open: func() (io.ReadCloser, error) { return fh.Open() },
+				})
+			}
+		}
+		if len(files) == 0 {
+			return nil, &FieldError{"file", "at least one file is required"}
+		}
+	} else {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			name = r.Header.Get("X-File-Name")
+		}
+		f, cleanup, err := rawUploadFile(r, name)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		files = []uploadFile{f}
+	}
+	for _, f := range files {
+		if f.name == "" || f.name == "." || f.name == ".." || strings.ContainsAny(f.name, `/\`) {
+			return nil, &FieldError{"name", "invalid file name " + strconv.Quote(f.name)}
+		}
+	}
+	uid, _ := strconv.Atoi(r.URL.Query().Get("uid"))
+	gid, _ := strconv.Atoi(r.URL.Query().Get("gid"))
+
+	// 루트 기준 상대 경로로 tar를 만들면 중간 디렉터리가 자동 생성됨
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeUploadTar(pw, files, strings.TrimPrefix(dir, "/"), uid, gid))
+	}()
+	defer pr.Close()
+	if err := copyTo("/", pr); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, path.Join(dir, f.name))
+	}
+	return names, nil
+}
+
+// rawUploadFile wraps a raw request body as a single file. A body without a
+// Content-Length is spooled to a temporary file first, since the tar header
+// needs the size up front.
+func rawUploadFile(r *http.Request, name string) (uploadFile, func(), error) {
+	noop := func() {}
+	if name == "" {
+		return uploadFile{}, noop, &FieldError{"name", "name is required for a raw file body"}
+	}
+	if r.ContentLength >= 0 {
+		return uploadFile{name: name, size: r.ContentLength, open: func() (io.ReadCloser, error) { return io.NopCloser(r.Body), nil }}, noop, nil
+	}
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return uploadFile{}, noop, err
+	}
+	cleanup := func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+	size, err := io.Copy(tmp, r.Body)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		cleanup()
+		return uploadFile{}, noop, err
+	}
+	return uploadFile{name: name, size: size, open: func() (io.ReadCloser, error) { return io.NopCloser(tmp), nil }}, cleanup, nil
+}
+
+// writeUploadTar writes the uploaded files into a tar under prefix.
+func writeUploadTar(dst io.Writer, files []uploadFile, prefix string, uid, gid int) error {
+	tw := tar.NewWriter(dst)
+	for _, uf := range files {
+		f, err := uf.open()
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{
+			Name:     path.Join(prefix, uf.name),
+			Mode:     0o644,
+			Size:     uf.size,
+			ModTime:  time.Now(),
+			Typeflag: tar.TypeReg,
+			Uid:      uid,
+			Gid:      gid,
+		}
+		err = tw.WriteHeader(hdr)
+		if err == nil {
+			_, err = io.Copy(tw, f)
+		}
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// WriteUploadError answers a CopyUpload error: 400 for a bad request,
+// otherwise the Docker error mapping.
+func WriteUploadError(w http.ResponseWriter, err error) {
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	WriteDockerError(w, err)
+}
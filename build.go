@@ -0,0 +1,568 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// 업로드 가능한 빌드 컨텍스트 최대 크기
+const maxBuildContextSize = 512 << 20
+
+// POST /go/images/build/context
+// Builds an image with cli.ImageBuild from a client-supplied context. Either
+// send multipart/form-data with a "context" file (tar or tar.gz) and the
+// BuildContextRequest fields as form values, or send the tar as the raw body
+// with the fields as query parameters. dockerfile_content alone (no context
+// file) builds from an empty context.
+func BuildImageFromContextHandler(w http.ResponseWriter, r *http.Request) {
+	ClearDeadlines(w)
+	r.Body = http.MaxBytesReader(w, r.Body, maxBuildContextSize)
+
+	values := r.URL.Query()
+	var buildCtx io.Reader
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid multipart body: " + err.Error()})
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+		values = url.Values(r.MultipartForm.Value)
+		if f, _, err := r.FormFile("context"); err == nil {
+			defer f.Close()
+			buildCtx = f
+		} else if !errors.Is(err, http.ErrMissingFile) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error(), Field: "context"})
+			return
+		}
+	} else if r.ContentLength != 0 {
+		buildCtx = r.Body
+	}
+
+	req, err := ParseBuildContextRequest(values)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	if buildCtx == nil && req.DockerfileContent == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "context archive or dockerfile_content is required", Field: "context"})
+		return
+	}
+	if req.DockerfileContent != "" {
+		rc := ContextWithDockerfile(buildCtx, req.Dockerfile, req.DockerfileContent)
+		defer rc.Close() // ImageBuild가 실패해도 재압축 goroutine이 종료되도록
+		buildCtx = rc
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	resp, err := cli.ImageBuild(r.Context(), buildCtx, req.ImageBuildOptions())
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer resp.Body.Close()
+
+	if values.Get("stream") == "true" || r.URL.Query().Get("stream") == "true" {
+		streamBuild(w, resp.Body, req)
+		return
+	}
+
+	res := collectBuild(resp.Body)
+	if res.err != nil {
+		WriteJSON(w, http.StatusInternalServerError, map[string]any{
+			"success": false,
+			"output":  res.output,
+			"error":   res.err.Error(),
+			"steps":   res.steps,
+		})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"success":  true,
+		"output":   res.output,
+		"image_id": res.imageID,
+		"tags":     req.Tags,
+		"steps":    res.steps,
+	})
+}
+
+type buildResult struct {
+	output  string
+	imageID string
+	steps   []BuildStep
+	err     error
+}
+
+// collectBuild reads a whole build response. The classic builder's text is
+// kept as is; BuildKit progress is rendered with BuildKitOutputLine.
+func collectBuild(body io.Reader) buildResult {
+	var output strings.Builder
+	var res buildResult
+	parser := &BuildStepParser{}
+	res.err = DecodeJSONMessages(body, func(m jsonmessage.JSONMessage) error {
+		output.WriteString(m.Stream)
+		events := parser.Feed(m)
+		if m.ID == buildKitTraceID {
+			for _, ev := range events {
+				output.WriteString(BuildKitOutputLine(ev))
+			}
+		}
+		if id := BuildResultID(m); id != "" {
+			res.imageID = id
+		}
+		return nil
+	})
+	for _, ev := range parser.Flush() {
+		output.WriteString(BuildKitOutputLine(ev))
+	}
+	res.output = output.String()
+	res.steps = parser.Steps
+	return res
+}
+
+// streamBuild forwards a build as SSE, one event per BuildEvent with the
+// event name set to its type, ending with "done" or "error".
+func streamBuild(w http.ResponseWriter, body io.Reader, req BuildContextRequest) {
+	sse, err := NewSSEStream(w)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer sse.Close()
+	sse.KeepAlive(15 * time.Second)
+
+	var imageID string
+	parser := &BuildStepParser{}
+	err = DecodeJSONMessages(body, func(m jsonmessage.JSONMessage) error {
+		if id := BuildResultID(m); id != "" {
+			imageID = id
+		}
+		for _, ev := range parser.Feed(m) {
+			if err := sse.Send(ev.Type, ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	for _, ev := range parser.Flush() {
+		_ = sse.Send(ev.Type, ev)
+	}
+	if err != nil {
+		_ = sse.Send("error", BuildEvent{Type: "error", Step: parser.Current(), Error: err.Error()})
+		return
+	}
+	_ = sse.Send("done", BuildEvent{Type: "done", ImageID: imageID, Tags: req.Tags})
+}
+
+// ParseBuildContextRequest reads build options from form or query values.
+// tags may be repeated or comma separated; build_args and labels are
+// repeated KEY=VALUE pairs or a JSON object.
+func ParseBuildContextRequest(values url.Values) (BuildContextRequest, error) {
+	req := BuildContextRequest{
+		Tags:              SplitValues(values["tags"]),
+		Dockerfile:        values.Get("dockerfile"),
+		DockerfileContent: values.Get("dockerfile_content"),
+		Target:            values.Get("target"),
+		Platform:          values.Get("platform"),
+		NoCache:           values.Get("no_cache") == "true",
+		Pull:              values.Get("pull") == "true",
+		Builder:           values.Get("builder"),
+	}
+	switch req.Builder {
+	case "", "buildkit", "classic":
+	default:
+		return req, &FieldError{"builder", "must be buildkit or classic"}
+	}
+	var err error
+	if req.BuildArgs, err = ParseKeyValues(values["build_args"]); err != nil {
+		return req, &FieldError{"build_args", err.Error()}
+	}
+	if req.Labels, err = ParseKeyValues(values["labels"]); err != nil {
+		return req, &FieldError{"labels", err.Error()}
+	}
+	if req.Dockerfile == "" {
+		req.Dockerfile = "Dockerfile"
+	}
+	if strings.HasPrefix(req.Dockerfile, "/") || strings.HasPrefix(path.Clean(req.Dockerfile), "..") {
+		return req, &FieldError{"dockerfile", "must be a relative path inside the context"}
+	}
+	return req, nil
+}
+
+// ImageBuildOptions maps the request onto the SDK options. Without a
+// builder the version is left to the daemon.
+func (req BuildContextRequest) ImageBuildOptions() types.ImageBuildOptions {
+	args := make(map[string]*string, len(req.BuildArgs))
+	for k, v := range req.BuildArgs {
+		args[k] = &v
+	}
+	var version types.BuilderVersion
+	switch req.Builder {
+	case "buildkit":
+		version = types.BuilderBuildKit
+	case "classic":
+		version = types.BuilderV1
+	}
+	return types.ImageBuildOptions{
+		Tags:        req.Tags,
+		Dockerfile:  req.Dockerfile,
+		BuildArgs:   args,
+		Labels:      req.Labels,
+		Target:      req.Target,
+		NoCache:     req.NoCache,
+		PullParent:  req.Pull,
+		Platform:    req.Platform,
+		Remove:      true,
+		ForceRemove: true,
+		Version:     version,
+	}
+}
+
+// BuildResultID returns the image ID carried in a build's aux message, if any.
+func BuildResultID(m jsonmessage.JSONMessage) string {
+	if m.Aux == nil {
+		return ""
+	}
+	var res types.BuildResult
+	if err := json.Unmarshal(*m.Aux, &res); err != nil {
+		return ""
+	}
+	return res.ID
+}
+
+// ContextWithDockerfile re-streams a (possibly gzipped) tar context with
+// content stored at name, replacing any file already there. A nil context
+// yields a tar holding only the Dockerfile.
+func ContextWithDockerfile(buildCtx io.Reader, name, content string) io.ReadCloser {
+	name = path.Clean(name)
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := func() error {
+			if buildCtx != nil {
+				src, err := decompressContext(buildCtx)
+				if err != nil {
+					return err
+				}
+				tr := tar.NewReader(src)
+				for {
+					hdr, err := tr.Next()
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					if err != nil {
+						return fmt.Errorf("invalid context archive: %w", err)
+					}
+					if path.Clean(strings.TrimPrefix(hdr.Name, "./")) == name {
+						continue
+					}
+					if err := tw.WriteHeader(hdr); err != nil {
+						return err
+					}
+					if _, err := io.Copy(tw, tr); err != nil {
+						return err
+					}
+				}
+			}
+			hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), ModTime: time.Now(), Typeflag: tar.TypeReg}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			_, err := io.WriteString(tw, content)
+			return err
+		}()
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// DirContext streams a server-side directory as a tar build context,
+// leaving out what its .dockerignore excludes.
+func DirContext(dir string) (io.ReadCloser, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	ignore, err := readDockerignore(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil || rel == "." {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if ignore.Excludes(rel) {
+				if d.IsDir() && !ignore.HasExceptions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			var link string
+			if info.Mode()&fs.ModeSymlink != 0 {
+				if link, err = os.Readlink(p); err != nil {
+					return err
+				}
+			}
+			hdr, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			hdr.Name = rel
+			if info.IsDir() {
+				hdr.Name += "/"
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// dockerignore holds .dockerignore patterns in file order. Patterns use
+// filepath.Match syntax plus "**" for any number of directories; a leading
+// "!" re-includes paths, and the last matching pattern wins.
+type dockerignore []string
+
+func readDockerignore(name string) (dockerignore, error) {
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var patterns dockerignore
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		neg := strings.HasPrefix(line, "!")
+		line = path.Clean(strings.TrimPrefix(strings.TrimPrefix(line, "!"), "/"))
+		if neg {
+			line = "!" + line
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, nil
+}
+
+// Excludes reports whether rel (slash separated, relative to the context
+// root) is ignored. A pattern matching a directory also covers its contents.
+func (d dockerignore) Excludes(rel string) bool {
+	excluded := false
+	for _, p := range d {
+		neg := strings.HasPrefix(p, "!")
+		if ignoreMatch(strings.TrimPrefix(p, "!"), rel) {
+			excluded = !neg
+		}
+	}
+	return excluded
+}
+
+// HasExceptions reports whether any pattern starts with "!", in which case
+// excluded directories still have to be walked.
+func (d dockerignore) HasExceptions() bool {
+	for _, p := range d {
+		if strings.HasPrefix(p, "!") {
+			return true
+		}
+	}
+	return false
+}
+
+func ignoreMatch(pattern, rel string) bool {
+	pp := strings.Split(pattern, "/")
+	rp := strings.Split(rel, "/")
+	// rel의 상위 디렉터리가 패턴과 일치해도 제외 대상
+	for n := len(rp); n > 0; n-- {
+		if matchSegments(pp, rp[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// decompressContext transparently un-gzips a context archive.
+func decompressContext(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+var buildStepRe = regexp.MustCompile(`^Step (\d+)/(\d+) : (.*)$`)
+
+// BuildStepParser turns build output into structured BuildEvents and keeps a
+// summary of every step seen so far. It understands both the classic
+// builder's "Step N/M" text and BuildKit's trace messages (build_trace.go).
+type BuildStepParser struct {
+	Steps   []BuildStep
+	partial string
+
+	vertexes map[string]int    // BuildKit vertex digest -> index in Steps
+	logParts map[string]string // BuildKit log output without a trailing newline
+	failed   int               // index+1 of the first failed BuildKit step
+}
+
+// Current returns the number of the failed step, or else of the step in
+// progress; 0 before the first.
+func (p *BuildStepParser) Current() int {
+	if p.failed > 0 {
+		return p.Steps[p.failed-1].Number
+	}
+	if len(p.Steps) == 0 {
+		return 0
+	}
+	return p.Steps[len(p.Steps)-1].Number
+}
+
+// Feed consumes one message of the build stream.
+func (p *BuildStepParser) Feed(m jsonmessage.JSONMessage) []BuildEvent {
+	if m.ID == buildKitTraceID {
+		st, err := DecodeBuildKitTrace(m)
+		if err != nil {
+			return nil
+		}
+		return p.feedTrace(st)
+	}
+	var events []BuildEvent
+	if m.Status != "" {
+		// FROM 베이스 이미지 pull 진행 상황
+		ev := NewPullProgressEvent(m)
+		events = append(events, BuildEvent{Type: "pull", Step: p.Current(), Line: ev.Status, LayerID: ev.ID, Percent: ev.Percent})
+	}
+	if m.Stream == "" {
+		return events
+	}
+	text := p.partial + m.Stream
+	lines := strings.Split(text, "\n")
+	p.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		events = append(events, p.line(strings.TrimRight(line, "\r"))...)
+	}
+	return events
+}
+
+// Flush emits trailing output that had no newline.
+func (p *BuildStepParser) Flush() []BuildEvent {
+	events := p.flushTrace()
+	if p.partial == "" {
+		return events
+	}
+	line := p.partial
+	p.partial = ""
+	return append(events, p.line(line)...)
+}
+
+func (p *BuildStepParser) line(line string) []BuildEvent {
+	if m := buildStepRe.FindStringSubmatch(line); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		total, _ := strconv.Atoi(m[2])
+		p.Steps = append(p.Steps, BuildStep{Number: n, Total: total, Instruction: m[3]})
+		return []BuildEvent{{Type: "step", Step: n, TotalSteps: total, Instruction: m[3]}}
+	}
+
+	var cur *BuildStep
+	if len(p.Steps) > 0 {
+		cur = &p.Steps[len(p.Steps)-1]
+	}
+	if rest, ok := strings.CutPrefix(line, " ---> "); ok && cur != nil {
+		switch {
+		case rest == "Using cache":
+			cur.Cached = true
+			return nil
+		case strings.HasPrefix(rest, "Running in "):
+			return nil
+		case !strings.Contains(rest, " "):
+			// " ---> <id>" 는 해당 스텝이 만든 레이어(이미지) ID
+			cur.LayerID = rest
+			cur.Done = true
+			return []BuildEvent{{Type: "step_done", Step: cur.Number, TotalSteps: cur.Total, Instruction: cur.Instruction, Cached: cur.Cached, LayerID: rest}}
+		}
+	}
+	if strings.HasPrefix(line, "Removing intermediate container ") || line == "" {
+		return nil
+	}
+	return []BuildEvent{{Type: "log", Step: p.Current(), Line: line}}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+func feedAll(p *BuildStepParser, msgs ...jsonmessage.JSONMessage) []BuildEvent {
+	var events []BuildEvent
+	for _, m := range msgs {
+		events = append(events, p.Feed(m)...)
+	}
+	return append(events, p.Flush()...)
+}
+
+func streamMsgs(chunks ...string) []jsonmessage.JSONMessage {
+	msgs := make([]jsonmessage.JSONMessage, len(chunks))
+	for i, c := range chunks {
+		msgs[i] = jsonmessage.JSONMessage{Stream: c}
+	}
+	return msgs
+}
+
+func TestBuildStepParserClassic(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   []BuildEvent
+		steps  []BuildStep
+	}{
+		{
+			name: "cached step",
+			chunks: []string{
+				"Step 1/2 : FROM alpine\n",
+				" ---> 1234abcd\n",
+				"Step 2/2 : RUN echo hi\n",
+				" ---> Using cache\n",
+				" ---> 5678ef01\n",
+			},
+			want: []BuildEvent{
+				{Type: "step", Step: 1, TotalSteps: 2, Instruction: "FROM alpine"},
+				{Type: "step_done", Step: 1, TotalSteps: 2, Instruction: "FROM alpine", LayerID: "1234abcd"},
+				{Type: "step", Step: 2, TotalSteps: 2, Instruction: "RUN echo hi"},
+				{Type: "step_done", Step: 2, TotalSteps: 2, Instruction: "RUN echo hi", Cached: true, LayerID: "5678ef01"},
+			},
+			steps: []BuildStep{
+				{Number: 1, Total: 2, Instruction: "FROM alpine", LayerID: "1234abcd", Done: true},
+				{Number: 2, Total: 2, Instruction: "RUN echo hi", Cached: true, LayerID: "5678ef01", Done: true},
+			},
+		},
+		{
+			name: "lines split across messages",
+			chunks: []string{
+				"Step 1/1 : RUN echo \"a b\"\n ---> Runn",
+				"ing in abc\nhello wor",
+				"ld\r\nRemoving intermediate container abc\n",
+				"last line",
+			},
+			want: []BuildEvent{
+				{Type: "step", Step: 1, TotalSteps: 1, Instruction: "RUN echo \"a b\""},
+				{Type: "log", Step: 1, Line: "hello world"},
+				{Type: "log", Step: 1, Line: "last line"},
+			},
+			steps: []BuildStep{{Number: 1, Total: 1, Instruction: "RUN echo \"a b\""}},
+		},
+		{
+			name:   "output before the first step",
+			chunks: []string{"Sending build context\n"},
+			want:   []BuildEvent{{Type: "log", Line: "Sending build context"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &BuildStepParser{}
+			got := feedAll(p, streamMsgs(tt.chunks...)...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(p.Steps, tt.steps) {
+				t.Errorf("steps = %+v, want %+v", p.Steps, tt.steps)
+			}
+		})
+	}
+}
+
+// protobuf helpers for building moby.buildkit.trace payloads
+func pbBytes(num int, data string) []byte {
+	b := []byte{byte(num<<3 | 2), byte(len(data))}
+	return append(b, data...)
+}
+
+func pbVarint(num int, v byte) []byte {
+	return []byte{byte(num << 3), v}
+}
+
+func pbMessage(parts ...[]byte) string {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return string(b)
+}
+
+func traceMsg(t *testing.T, parts ...[]byte) jsonmessage.JSONMessage {
+	t.Helper()
+	raw, err := json.Marshal(base64.StdEncoding.EncodeToString([]byte(pbMessage(parts...))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aux := json.RawMessage(raw)
+	return jsonmessage.JSONMessage{ID: buildKitTraceID, Aux: &aux}
+}
+
+func TestBuildStepParserBuildKit(t *testing.T) {
+	timestamp := pbMessage(pbVarint(1, 1))
+	vertex := func(digest, name string, extra ...[]byte) []byte {
+		return pbBytes(1, pbMessage(append([][]byte{pbBytes(1, digest), pbBytes(3, name)}, extra...)...))
+	}
+	vlog := func(digest, msg string) []byte {
+		return pbBytes(3, pbMessage(pbBytes(1, digest), pbBytes(4, msg)))
+	}
+
+	p := &BuildStepParser{}
+	got := feedAll(p,
+		traceMsg(t, vertex("sha256:i", "[internal] load build definition from Dockerfile", pbBytes(5, timestamp))),
+		traceMsg(t, vertex("sha256:a", "[builder 1/2] FROM docker.io/library/alpine", pbBytes(5, timestamp))),
+		traceMsg(t, vertex("sha256:a", "[builder 1/2] FROM docker.io/library/alpine", pbVarint(4, 1), pbBytes(6, timestamp))),
+		traceMsg(t, vertex("sha256:b", "[builder 2/2] RUN printf 'a b\\nc'", pbBytes(5, timestamp)), vlog("sha256:b", "a b\nhalf ")),
+		traceMsg(t, vlog("sha256:b", "line\n"), vlog("sha256:b", "no newline")),
+		traceMsg(t, vertex("sha256:b", "[builder 2/2] RUN printf 'a b\\nc'", pbBytes(7, "exit code: 1"))),
+		jsonmessage.JSONMessage{Stream: ""},
+	)
+	want := []BuildEvent{
+		{Type: "step", Step: 1, TotalSteps: 2, Stage: "builder", Instruction: "FROM docker.io/library/alpine"},
+		{Type: "step_done", Step: 1, TotalSteps: 2, Stage: "builder", Instruction: "FROM docker.io/library/alpine", Cached: true},
+		{Type: "step", Step: 2, TotalSteps: 2, Stage: "builder", Instruction: "RUN printf 'a b\\nc'"},
+		{Type: "log", Step: 2, Line: "a b"},
+		{Type: "log", Step: 2, Line: "half line"},
+		{Type: "log", Step: 2, Line: "no newline"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %+v, want %+v", got, want)
+	}
+	if len(p.Steps) != 2 || p.Steps[1].Error != "exit code: 1" || p.Steps[1].Done {
+		t.Errorf("steps = %+v", p.Steps)
+	}
+	if cur := p.Current(); cur != 2 {
+		t.Errorf("Current() = %d, want the failed step 2", cur)
+	}
+}
+
+func TestBuildStepParserBuildKitStatuses(t *testing.T) {
+	vertex := func(digest, name string) []byte {
+		return pbBytes(1, pbMessage(pbBytes(1, digest), pbBytes(3, name)))
+	}
+	status := func(id, digest, name string, current, total byte) []byte {
+		return pbBytes(2, pbMessage(pbBytes(1, id), pbBytes(2, digest), pbBytes(3, name), pbVarint(4, current), pbVarint(5, total)))
+	}
+
+	p := &BuildStepParser{}
+	got := feedAll(p,
+		traceMsg(t, vertex("sha256:c", "[internal] load build context"), status("context", "sha256:c", "transferring context", 0, 0)),
+		traceMsg(t, vertex("sha256:f", "[1/2] FROM docker.io/library/alpine"), status("sha256:layer", "sha256:f", "", 25, 100)),
+		traceMsg(t, vertex("sha256:e", "exporting to image"), status("writing", "sha256:e", "writing image", 0, 0)),
+	)
+	want := []BuildEvent{
+		{Type: "progress", Line: "transferring context", LayerID: "context"},
+		{Type: "step", Step: 1, TotalSteps: 2, Instruction: "FROM docker.io/library/alpine"},
+		{Type: "pull", Step: 1, Line: "sha256:layer", LayerID: "sha256:layer", Percent: 25},
+		{Type: "progress", Line: "writing image", LayerID: "writing"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeBuildKitTraceInvalid(t *testing.T) {
+	raw := json.RawMessage(`"` + base64.StdEncoding.EncodeToString([]byte{0x0a, 0x05, 'a'}) + `"`)
+	if _, err := DecodeBuildKitTrace(jsonmessage.JSONMessage{ID: buildKitTraceID, Aux: &raw}); err == nil {
+		t.Error("expected an error for a truncated payload")
+	}
+	p := &BuildStepParser{}
+	if events := p.Feed(jsonmessage.JSONMessage{ID: buildKitTraceID, Aux: &raw}); events != nil {
+		t.Errorf("Feed() = %+v, want no events", events)
+	}
+}
+
+func TestDockerignore(t *testing.T) {
+	ignore := dockerignore{"node_modules", "**/*.log", "!src/keep.log", "build/*"}
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{"node_modules", true},
+		{"node_modules/pkg/index.js", true},
+		{"app.log", true},
+		{"src/deep/debug.log", true},
+		{"src/keep.log", false},
+		{"build", false},
+		{"build/out", true},
+		{"src/main.go", false},
+		{"my file.txt", false},
+	}
+	for _, tt := range tests {
+		if got := ignore.Excludes(tt.rel); got != tt.want {
+			t.Errorf("Excludes(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+}
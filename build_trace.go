@@ -0,0 +1,268 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// BuildKit builds report progress as aux messages with this ID whose payload
+// is a protobuf-encoded moby/buildkit control.StatusResponse.
+const buildKitTraceID = "moby.buildkit.trace"
+
+// "[2/5] RUN make" 또는 멀티 스테이지의 "[builder 2/5] RUN make"
+var buildKitStepRe = regexp.MustCompile(`^\[(?:(\S+) )?(\d+)/(\d+)\] (.*)$`)
+
+type buildKitVertex struct {
+	Digest    string
+	Name      string
+	Cached    bool
+	Started   bool
+	Completed bool
+	Error     string
+}
+
+type buildKitVertexStatus struct {
+	ID      string
+	Vertex  string
+	Name    string
+	Current int64
+	Total   int64
+}
+
+type buildKitVertexLog struct {
+	Vertex string
+	Msg    []byte
+}
+
+type buildKitStatus struct {
+	Vertexes []buildKitVertex
+	Statuses []buildKitVertexStatus
+	Logs     []buildKitVertexLog
+}
+
+// DecodeBuildKitTrace decodes the payload of a moby.buildkit.trace aux
+// message. Only the fields the step parser needs are read.
+func DecodeBuildKitTrace(m jsonmessage.JSONMessage) (buildKitStatus, error) {
+	var st buildKitStatus
+	if m.ID != buildKitTraceID || m.Aux == nil {
+		return st, errors.New("not a buildkit trace message")
+	}
+	var dt []byte
+	if err := json.Unmarshal(*m.Aux, &dt); err != nil {
+		return st, err
+	}
+	err := protoFields(dt, func(num int, _ uint64, data []byte) error {
+		switch num {
+		case 1:
+			var v buildKitVertex
+			err := protoFields(data, func(num int, x uint64, data []byte) error {
+				switch num {
+				case 1:
+					v.Digest = string(data)
+				case 3:
+					v.Name = string(data)
+				case 4:
+					v.Cached = x != 0
+				case 5:
+					v.Started = true
+				case 6:
+					v.Completed = true
+				case 7:
+					v.Error = string(data)
+				}
+				return nil
+			})
+			st.Vertexes = append(st.Vertexes, v)
+			return err
+		case 2:
+			var s buildKitVertexStatus
+			err := protoFields(data, func(num int, x uint64, data []byte) error {
+				switch num {
+				case 1:
+					s.ID = string(data)
+				case 2:
+					s.Vertex = string(data)
+				case 3:
+					s.Name = string(data)
+				case 4:
+					s.Current = int64(x)
+				case 5:
+					s.Total = int64(x)
+				}
+				return nil
+			})
+			st.Statuses = append(st.Statuses, s)
+			return err
+		case 3:
+			var l buildKitVertexLog
+			err := protoFields(data, func(num int, _ uint64, data []byte) error {
+				switch num {
+				case 1:
+					l.Vertex = string(data)
+				case 4:
+					l.Msg = data
+				}
+				return nil
+			})
+			st.Logs = append(st.Logs, l)
+			return err
+		}
+		return nil
+	})
+	return st, err
+}
+
+// protoFields walks the top-level fields of a protobuf message, passing
+// varints in v and length-delimited fields in data.
+func protoFields(b []byte, fn func(num int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("invalid protobuf field key")
+		}
+		b = b[n:]
+		num := int(key >> 3)
+		var v uint64
+		var data []byte
+		switch key & 7 {
+		case 0:
+			if v, n = binary.Uvarint(b); n <= 0 {
+				return errors.New("invalid protobuf varint")
+			}
+			b = b[n:]
+		case 1:
+			if len(b) < 8 {
+				return errors.New("truncated protobuf field")
+			}
+			b = b[8:]
+		case 2:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errors.New("truncated protobuf field")
+			}
+			data = b[n : n+int(l)]
+			b = b[n+int(l):]
+		case 5:
+			if len(b) < 4 {
+				return errors.New("truncated protobuf field")
+			}
+			b = b[4:]
+		default:
+			return errors.New("unsupported protobuf wire type " + strconv.Itoa(int(key&7)))
+		}
+		if err := fn(num, v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// feedTrace turns one BuildKit status update into events. Vertexes named
+// like "[2/5] RUN make" are Dockerfile steps; "[internal] ..." vertexes and
+// the export are not reported as steps, but their logs are.
+func (p *BuildStepParser) feedTrace(st buildKitStatus) []BuildEvent {
+	if p.vertexes == nil {
+		p.vertexes = map[string]int{}
+		p.logParts = map[string]string{}
+	}
+	var events []BuildEvent
+	for _, v := range st.Vertexes {
+		i, ok := p.vertexes[v.Digest]
+		if !ok {
+			m := buildKitStepRe.FindStringSubmatch(v.Name)
+			if m == nil {
+				continue
+			}
+			n, _ := strconv.Atoi(m[2])
+			total, _ := strconv.Atoi(m[3])
+			p.Steps = append(p.Steps, BuildStep{Number: n, Total: total, Stage: m[1], Instruction: m[4]})
+			i = len(p.Steps) - 1
+			p.vertexes[v.Digest] = i
+			events = append(events, BuildEvent{Type: "step", Step: n, TotalSteps: total, Stage: m[1], Instruction: m[4]})
+		}
+		step := &p.Steps[i]
+		if v.Error != "" && step.Error == "" {
+			step.Error = v.Error
+			p.failed = i + 1
+		}
+		if v.Completed && !step.Done {
+			step.Done = true
+			step.Cached = v.Cached
+			events = append(events, BuildEvent{Type: "step_done", Step: step.Number, TotalSteps: step.Total, Stage: step.Stage, Instruction: step.Instruction, Cached: step.Cached})
+		}
+	}
+	for _, s := range st.Statuses {
+		// 컨텍스트 전송·export 등도 status로 오므로 FROM 스텝만 pull로 보고
+		ev := BuildEvent{Type: "progress", Step: p.vertexStep(s.Vertex), Line: s.Name, LayerID: s.ID}
+		if i, ok := p.vertexes[s.Vertex]; ok && strings.HasPrefix(p.Steps[i].Instruction, "FROM ") {
+			ev.Type = "pull"
+		}
+		if ev.Line == "" {
+			ev.Line = s.ID
+		}
+		if s.Total > 0 {
+			ev.Percent = float64(s.Current) / float64(s.Total) * 100
+		}
+		events = append(events, ev)
+	}
+	for _, l := range st.Logs {
+		lines := strings.Split(p.logParts[l.Vertex]+string(l.Msg), "\n")
+		p.logParts[l.Vertex] = lines[len(lines)-1]
+		for _, line := range lines[:len(lines)-1] {
+			events = append(events, BuildEvent{Type: "log", Step: p.vertexStep(l.Vertex), Line: strings.TrimRight(line, "\r")})
+		}
+	}
+	return events
+}
+
+// flushTrace emits unterminated log output, in step order.
+func (p *BuildStepParser) flushTrace() []BuildEvent {
+	vertexes := make([]string, 0, len(p.logParts))
+	for v, part := range p.logParts {
+		if part != "" {
+			vertexes = append(vertexes, v)
+		}
+	}
+	sort.Slice(vertexes, func(i, j int) bool { return p.vertexes[vertexes[i]] < p.vertexes[vertexes[j]] })
+	var events []BuildEvent
+	for _, v := range vertexes {
+		events = append(events, BuildEvent{Type: "log", Step: p.vertexStep(v), Line: p.logParts[v]})
+		delete(p.logParts, v)
+	}
+	return events
+}
+
+func (p *BuildStepParser) vertexStep(digest string) int {
+	if i, ok := p.vertexes[digest]; ok {
+		return p.Steps[i].Number
+	}
+	return 0
+}
+
+// BuildKitOutputLine renders a BuildKit event as a plain text output line,
+// so non-streamed builds still return readable output.
+func BuildKitOutputLine(ev BuildEvent) string {
+	prefix := "[" + strconv.Itoa(ev.Step) + "/" + strconv.Itoa(ev.TotalSteps) + "] "
+	if ev.Stage != "" {
+		prefix = "[" + ev.Stage + " " + prefix[1:]
+	}
+	switch ev.Type {
+	case "step":
+		return prefix + ev.Instruction + "\n"
+	case "step_done":
+		if ev.Cached {
+			return prefix + "CACHED\n"
+		}
+		return prefix + "DONE\n"
+	case "log":
+		return ev.Line + "\n"
+	}
+	return ""
+}
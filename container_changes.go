@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/gorilla/mux"
+)
+
+// GET /go/containers/{id}/changes
+// Lists what changed in the container's writable layer compared to its
+// image, both as flat added/modified/deleted lists and as a directory tree.
+func ContainerChangesHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	changes, err := cli.ContainerDiff(ctx, id)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, NewContainerChanges(changes))
+}
+
+// NewContainerChanges groups a ContainerDiff result by kind and builds the
+// tree. Directories that only contain changes get an empty kind.
+func NewContainerChanges(changes []container.FilesystemChange) ContainerChanges {
+	res := ContainerChanges{
+		Added:    []string{},
+		Modified: []string{},
+		Deleted:  []string{},
+		Tree:     &ChangeNode{Name: "/", Path: "/"},
+	}
+	for _, c := range changes {
+		kind := changeKind(c.Kind)
+		switch c.Kind {
+		case container.ChangeAdd:
+			res.Added = append(res.Added, c.Path)
+		case container.ChangeModify:
+			res.Modified = append(res.Modified, c.Path)
+		case container.ChangeDelete:
+			res.Deleted = append(res.Deleted, c.Path)
+		}
+
+		node := res.Tree
+		for _, part := range strings.Split(strings.Trim(c.Path, "/"), "/") {
+			if part == "" {
+				continue
+			}
+			node = node.child(part)
+		}
+		node.Kind = kind
+	}
+	sort.Strings(res.Added)
+	sort.Strings(res.Modified)
+	sort.Strings(res.Deleted)
+	res.Tree.sort()
+	res.Total = len(changes)
+	return res
+}
+
+func changeKind(k container.ChangeType) string {
+	switch k {
+	case container.ChangeAdd:
+		return "added"
+	case container.ChangeModify:
+		return "modified"
+	case container.ChangeDelete:
+		return "deleted"
+	}
+	return ""
+}
+
+func (n *ChangeNode) child(name string) *ChangeNode {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	c := &ChangeNode{Name: name, Path: strings.TrimSuffix(n.Path, "/") + "/" + name}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+func (n *ChangeNode) sort() {
+	sort.Slice(n.Children, func(i, j int) bool { return n.Children[i].Name < n.Children[j].Name })
+	for _, c := range n.Children {
+		c.sort()
+	}
+}
+
+// POST /go/containers/{id}/commit  {"reference": "myapp:step2", "changes": ["ENV MODE=dev"]}
+// Creates an image from the container. config fields left empty keep the
+// container's values.
+func CommitContainerHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req CommitContainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	opts := container.CommitOptions{
+		Reference: req.Reference,
+		Comment:   req.Comment,
+		Author:    req.Author,
+		Changes:   req.Changes,
+		Pause:     req.Pause == nil || *req.Pause,
+	}
+	if req.Config != nil {
+		cfg, err := req.Config.ContainerConfig()
+		if err != nil {
+			WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+			return
+		}
+		opts.Config = cfg
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	defer cancel()
+
+	resp, err := cli.ContainerCommit(ctx, id, opts)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusCreated, map[string]string{"status": "committed", "id": resp.ID, "reference": req.Reference})
+}
+
+// ContainerConfig converts the overrides into the partial config the daemon
+// merges with the container's own.
+func (c CommitConfig) ContainerConfig() (*container.Config, error) {
+	cfg := &container.Config{
+		Cmd:        c.Cmd,
+		Entrypoint: c.Entrypoint,
+		Env:        c.Env,
+		Labels:     c.Labels,
+		WorkingDir: c.WorkingDir,
+		User:       c.User,
+	}
+	if len(c.ExposedPorts) > 0 {
+		cfg.ExposedPorts = nat.PortSet{}
+		for _, spec := range c.ExposedPorts {
+			proto, port := nat.SplitProtoPort(spec)
+			p, err := nat.NewPort(proto, port)
+			if err != nil {
+				return nil, &FieldError{"config.exposed_ports", err.Error()}
+			}
+			cfg.ExposedPorts[p] = struct{}{}
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	units "github.com/docker/go-units"
+)
+
+// FieldError reports an invalid request field by its JSON path, e.g. "ports[0].container_port".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// NewErrorResponse wraps err, filling in the field name for a FieldError.
+func NewErrorResponse(err error) ErrorResponse {
+	resp := ErrorResponse{Error: err.Error()}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		resp.Field = fe.Field
+	}
+	return resp
+}
+
+// BuildContainerConfig validates req and maps it onto the SDK create structs.
+func BuildContainerConfig(req CreateContainerRequest) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+	cfg := &container.Config{
+		Image:      req.Image,
+		Cmd:        req.Cmd,
+		Env:        req.Env,
+		Entrypoint: req.Entrypoint,
+		WorkingDir: req.WorkingDir,
+		User:       req.User,
+		Labels:     req.Labels,
+		Tty:        false,
+	}
+	hostCfg := &container.HostConfig{}
+
+	if req.WorkingDir != "" && !strings.HasPrefix(req.WorkingDir, "/") {
+		return nil, nil, nil, &FieldError{"working_dir", "must be an absolute path"}
+	}
+	for k := range req.Labels {
+		if strings.TrimSpace(k) == "" {
+			return nil, nil, nil, &FieldError{"labels", "label key must not be empty"}
+		}
+	}
+
+	// Ports
+	if len(req.Ports) > 0 {
+		cfg.ExposedPorts = nat.PortSet{}
+		hostCfg.PortBindings = nat.PortMap{}
+	}
+	for i, p := range req.Ports {
+		field := fmt.Sprintf("ports[%d]", i)
+		if p.ContainerPort < 1 || p.ContainerPort > 65535 {
+			return nil, nil, nil, &FieldError{field + ".container_port", "must be between 1 and 65535"}
+		}
+		if p.HostPort < 0 || p.HostPort > 65535 {
+			return nil, nil, nil, &FieldError{field + ".host_port", "must be between 0 (random) and 65535"}
+		}
+		proto := strings.ToLower(p.Protocol)
+		if proto == "" {
+			proto = "tcp"
+		}
+		if proto != "tcp" && proto != "udp" && proto != "sctp" {
+			return nil, nil, nil, &FieldError{field + ".protocol", "must be tcp, udp or sctp"}
+		}
+		port, err := nat.NewPort(proto, fmt.Sprint(p.ContainerPort))
+		if err != nil {
+			return nil, nil, nil, &FieldError{field, err.Error()}
+		}
+		cfg.ExposedPorts[port] = struct{}{}
+		binding := nat.PortBinding{HostIP: p.HostIP}
+		if p.HostPort > 0 {
+			binding.HostPort = fmt.Sprint(p.HostPort)
+		}
+		hostCfg.PortBindings[port] = append(hostCfg.PortBindings[port], binding)
+	}
+
+	// Mounts
+	for i, m := range req.Mounts {
+		field := fmt.Sprintf("mounts[%d]", i)
+		if m.Target == "" || !strings.HasPrefix(m.Target, "/") {
+			return nil, nil, nil, &FieldError{field + ".target", "must be an absolute path inside the container"}
+		}
+		mt := mount.Type(strings.ToLower(m.Type))
+		if mt == "" {
+			mt = mount.TypeVolume
+		}
+		switch mt {
+		case mount.TypeBind:
+			if !filepath.IsAbs(m.Source) {
+				return nil, nil, nil, &FieldError{field + ".source", "bind mounts need an absolute host path"}
+			}
+		case mount.TypeVolume:
+			// 빈 source는 익명 볼륨
+		case mount.TypeTmpfs:
+			if m.Source != "" {
+				return nil, nil, nil, &FieldError{field + ".source", "must be empty for tmpfs mounts"}
+			}
+		default:
+			return nil, nil, nil, &FieldError{field + ".type", "must be bind, volume or tmpfs"}
+		}
+		hostCfg.Mounts = append(hostCfg.Mounts, mount.Mount{Type: mt, Source: m.Source, Target: m.Target, ReadOnly: m.ReadOnly})
+	}
+
+	// Restart policy
+	if req.RestartPolicy != nil {
+		rp, err := req.RestartPolicy.RestartPolicy()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		hostCfg.RestartPolicy = rp
+	}
+
+	// Resources
+	if req.Memory != "" {
+		mem, err := ParseMemory("memory", req.Memory)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		hostCfg.Memory = mem
+	}
+	if req.CPUs < 0 {
+		return nil, nil, nil, &FieldError{"cpus", "must not be negative"}
+	}
+	if req.CPUs > 0 {
+		hostCfg.NanoCPUs = int64(req.CPUs * 1e9)
+	}
+
+	// Healthcheck
+	if hc := req.Healthcheck; hc != nil {
+		if len(hc.Test) == 0 {
+			return nil, nil, nil, &FieldError{"healthcheck.test", "is required"}
+		}
+		test := hc.Test
+		switch test[0] {
+		case "NONE", "CMD", "CMD-SHELL":
+		default:
+			// ["curl -f http://localhost"] 처럼 명령만 주면 셸로 실행
+			test = []string{"CMD-SHELL", strings.Join(test, " ")}
+		}
+		health := &container.HealthConfig{Test: test, Retries: hc.Retries}
+		for _, d := range []struct {
+			field string
+			value string
+			dst   *time.Duration
+		}{
+			{"healthcheck.interval", hc.Interval, &health.Interval},
+			{"healthcheck.timeout", hc.Timeout, &health.Timeout},
+			{"healthcheck.start_period", hc.StartPeriod, &health.StartPeriod},
+		} {
+			if d.value == "" {
+				continue
+			}
+			v, err := time.ParseDuration(d.value)
+			if err != nil || v < 0 {
+				return nil, nil, nil, &FieldError{d.field, `must be a duration such as "30s"`}
+			}
+			*d.dst = v
+		}
+		if hc.Retries < 0 {
+			return nil, nil, nil, &FieldError{"healthcheck.retries", "must not be negative"}
+		}
+		cfg.Healthcheck = health
+	}
+
+	// Network
+	var netCfg *network.NetworkingConfig
+	if req.Network != "" {
+		hostCfg.NetworkMode = container.NetworkMode(req.Network)
+		netCfg = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				req.Network: {Aliases: req.NetworkAliases},
+			},
+		}
+	} else if len(req.NetworkAliases) > 0 {
+		return nil, nil, nil, &FieldError{"network_aliases", "requires network"}
+	}
+
+	return cfg, hostCfg, netCfg, nil
+}
+
+// RestartPolicy converts and validates the spec.
+func (s RestartPolicySpec) RestartPolicy() (container.RestartPolicy, error) {
+	rp := container.RestartPolicy{
+		Name:              container.RestartPolicyMode(s.Name),
+		MaximumRetryCount: s.MaxRetries,
+	}
+	if err := container.ValidateRestartPolicy(rp); err != nil {
+		return rp, &FieldError{"restart_policy", err.Error()}
+	}
+	return rp, nil
+}
+
+// ParseMemory parses a positive size such as "256m" or "1g" for field.
+func ParseMemory(field, v string) (int64, error) {
+	mem, err := units.RAMInBytes(v)
+	if err != nil || mem <= 0 {
+		return 0, &FieldError{field, `must be a size such as "256m" or "1g"`}
+	}
+	return mem, nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gorilla/mux"
+)
+
+// POST /go/containers/{id}/pause
+func PauseContainerHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	if err := cli.ContainerPause(ctx, id); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "paused", "id": id})
+}
+
+// POST /go/containers/{id}/unpause
+func UnpauseContainerHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	if err := cli.ContainerUnpause(ctx, id); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "unpaused", "id": id})
+}
+
+// POST /go/containers/{id}/kill?signal=SIGTERM
+// signal accepts a name with or without the SIG prefix or a number; the
+// default is SIGKILL.
+func KillContainerHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	signal := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("signal")))
+	if signal == "" {
+		signal = "SIGKILL"
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	if err := cli.ContainerKill(ctx, id, signal); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "killed", "id": id, "signal": signal})
+}
+
+// POST /go/containers/{id}/rename  {"name": "web-old"}
+func RenameContainerHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req RenameContainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	name := strings.TrimPrefix(req.Name, "/")
+	if name == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "name is required", Field: "name"})
+		return
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	if err := cli.ContainerRename(ctx, id, name); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "renamed", "id": id, "name": name})
+}
+
+// POST /go/containers/{id}/update
+// {"memory": "512m", "cpus": 1.5, "restart_policy": {"name": "on-failure", "max_retries": 3}}
+// Changes resource limits and the restart policy of a running container.
+// Only the fields given are changed.
+func UpdateContainerHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req UpdateContainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	cfg, err := req.UpdateConfig()
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	resp, err := cli.ContainerUpdate(ctx, id, cfg)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	warnings := resp.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{"status": "updated", "id": id, "warnings": warnings})
+}
+
+// UpdateConfig validates the request and maps it onto the SDK type.
+func (req UpdateContainerRequest) UpdateConfig() (container.UpdateConfig, error) {
+	var cfg container.UpdateConfig
+	var err error
+	if req.Memory != "" {
+		if cfg.Memory, err = ParseMemory("memory", req.Memory); err != nil {
+			return cfg, err
+		}
+	}
+	if req.MemoryReservation != "" {
+		if cfg.MemoryReservation, err = ParseMemory("memory_reservation", req.MemoryReservation); err != nil {
+			return cfg, err
+		}
+	}
+	switch req.MemorySwap {
+	case "":
+	case "-1":
+		cfg.MemorySwap = -1
+	default:
+		if cfg.MemorySwap, err = ParseMemory("memory_swap", req.MemorySwap); err != nil {
+			return cfg, err
+		}
+	}
+	if req.CPUs < 0 {
+		return cfg, &FieldError{"cpus", "must not be negative"}
+	}
+	cfg.NanoCPUs = int64(req.CPUs * 1e9)
+	if req.CPUShares < 0 {
+		return cfg, &FieldError{"cpu_shares", "must not be negative"}
+	}
+	cfg.CPUShares = req.CPUShares
+	cfg.PidsLimit = req.PidsLimit
+	if req.RestartPolicy != nil {
+		if cfg.RestartPolicy, err = req.RestartPolicy.RestartPolicy(); err != nil {
+			return cfg, err
+		}
+	}
+	if cfg.Memory == 0 && cfg.MemoryReservation == 0 && cfg.MemorySwap == 0 && cfg.NanoCPUs == 0 &&
+		cfg.CPUShares == 0 && cfg.PidsLimit == nil && req.RestartPolicy == nil {
+		return cfg, errors.New("nothing to update")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gorilla/mux"
+)
+
+// 컨테이너로 업로드 가능한 최대 크기
+const maxContainerUploadSize = 1 << 30
+
+// GET /go/containers/{id}/stat?path=/usr/share/nginx/html
+func ContainerStatPathHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	p := r.URL.Query().Get("path")
+	if p == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "path is required", Field: "path"})
+		return
+	}
+	p = path.Clean("/" + p)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	stat, err := cli.ContainerStatPath(ctx, id, p)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	resp := map[string]any{
+		"path":     p,
+		"name":     stat.Name,
+		"size":     stat.Size,
+		"mode":     stat.Mode.String(),
+		"mod_time": stat.Mtime,
+		"is_dir":   stat.Mode.IsDir(),
+	}
+	if stat.Mode&os.ModeSymlink != 0 {
+		resp["link_target"] = stat.LinkTarget
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// GET /go/containers/{id}/download?path=/etc/nginx&format=tar|zip|raw
+// Works on stopped containers too.
+func DownloadContainerPathHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	q := r.URL.Query()
+	if q.Get("path") == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "path is required", Field: "path"})
+		return
+	}
+	p := path.Clean("/" + q.Get("path"))
+	format, err := ArchiveFormat(q.Get("format"))
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	ClearDeadlines(w)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	rc, stat, err := cli.CopyFromContainer(r.Context(), id, p)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	defer rc.Close()
+
+	name := path.Base(p)
+	if p == "/" {
+		name = id
+	}
+	WritePathDownload(w, rc, stat, name, format)
+}
+
+// POST /go/containers/{id}/upload?path=/usr/share/nginx/html&uid=0&gid=0&name=index.html
+// Accepts multipart files, a tar body or a single raw file; see CopyUpload.
+func UploadContainerFilesHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	q := r.URL.Query()
+	if q.Get("path") == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "path is required", Field: "path"})
+		return
+	}
+	dir := path.Clean("/" + q.Get("path"))
+	ClearDeadlines(w)
+	r.Body = http.MaxBytesReader(w, r.Body, maxContainerUploadSize)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx := r.Context()
+	files, err := CopyUpload(r, dir, func(dst string, content io.Reader) error {
+		return cli.CopyToContainer(ctx, id, dst, content, container.CopyToContainerOptions{})
+	})
+	if err != nil {
+		WriteUploadError(w, err)
+		return
+	}
+	resp := map[string]any{"status": "uploaded", "id": id, "path": dir}
+	if files != nil {
+		resp["files"] = files
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/go-units"
+)
+
+// compose가 컨테이너에 붙이는 라벨
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+)
+
+var containerStatuses = map[string]bool{
+	"created": true, "restarting": true, "running": true, "removing": true,
+	"paused": true, "exited": true, "dead": true,
+}
+
+// ContainerListOptions reads the list query: all, status, label, name,
+// ancestor, network and project filters (repeatable or comma separated)
+// and size. A status filter implies all=true.
+func ContainerListOptions(r *http.Request) (container.ListOptions, error) {
+	q := r.URL.Query()
+	opts := container.ListOptions{
+		All:     q.Get("all") == "true",
+		Size:    q.Get("size") == "true",
+		Filters: filters.NewArgs(),
+	}
+	for _, s := range QueryValues(r, "status") {
+		if !containerStatuses[s] {
+			return opts, &FieldError{"status", "unknown status " + strconv.Quote(s)}
+		}
+		opts.Filters.Add("status", s)
+		opts.All = true
+	}
+	for _, key := range []string{"label", "name", "ancestor", "network"} {
+		for _, v := range QueryValues(r, key) {
+			opts.Filters.Add(key, v)
+		}
+	}
+	for _, p := range QueryValues(r, "project") {
+		opts.Filters.Add("label", composeProjectLabel+"="+p)
+	}
+	return opts, nil
+}
+
+// NewContainerSummary trims a list entry down to what a dashboard table
+// shows.
+func NewContainerSummary(c types.Container) ContainerSummary {
+	s := ContainerSummary{
+		ID:             c.ID[:12],
+		Name:           ContainerName(c),
+		Image:          c.Image,
+		Command:        c.Command,
+		State:          c.State,
+		Status:         c.Status,
+		Created:        time.Unix(c.Created, 0).UTC(),
+		Ports:          FormatPorts(c.Ports),
+		Networks:       []string{},
+		Mounts:         []string{},
+		Labels:         c.Labels,
+		ComposeProject: c.Labels[composeProjectLabel],
+		ComposeService: c.Labels[composeServiceLabel],
+	}
+	s.Uptime, s.Health = parseStatus(c.Status)
+	if c.NetworkSettings != nil {
+		for name := range c.NetworkSettings.Networks {
+			s.Networks = append(s.Networks, name)
+		}
+		sort.Strings(s.Networks)
+	}
+	for _, m := range c.Mounts {
+		src := m.Name
+		if src == "" {
+			src = m.Source
+		}
+		s.Mounts = append(s.Mounts, src+":"+m.Destination)
+	}
+	if c.SizeRw > 0 || c.SizeRootFs > 0 {
+		s.SizeRw = c.SizeRw
+		s.SizeRootFs = c.SizeRootFs
+		s.SizeHuman = fmt.Sprintf("%s (virtual %s)", units.HumanSize(float64(c.SizeRw)), units.HumanSize(float64(c.SizeRootFs)))
+	}
+	return s
+}
+
+// parseStatus extracts the uptime and health from a status text such as
+// "Up 3 hours (healthy)". Uptime is empty unless the container is up.
+func parseStatus(status string) (uptime, health string) {
+	rest, ok := strings.CutPrefix(status, "Up ")
+	if !ok {
+		return "", ""
+	}
+	if i := strings.Index(rest, " ("); i >= 0 {
+		switch h := strings.TrimSuffix(rest[i+2:], ")"); h {
+		case "healthy", "unhealthy":
+			health = h
+		case "health: starting":
+			health = "starting"
+		}
+		rest = rest[:i]
+	}
+	return rest, health
+}
+
+// FormatPorts renders ports the way `docker ps` does, e.g.
+// "0.0.0.0:8080->80/tcp" or "443/tcp" when not published.
+func FormatPorts(ports []types.Port) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, p := range ports {
+		var s string
+		if p.PublicPort != 0 {
+			ip := p.IP
+			if strings.Contains(ip, ":") {
+				ip = "[" + ip + "]"
+			}
+			s = fmt.Sprintf("%s:%d->%d/%s", ip, p.PublicPort, p.PrivatePort, p.Type)
+		} else {
+			s = fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ContainerPage holds the sort and pagination options of summary=true.
+type ContainerPage struct {
+	Sort   string
+	Desc   bool
+	Offset int
+	Limit  int // 0 = no limit
+}
+
+// ParseContainerPage reads sort, order, offset and limit. They only apply to
+// the summary=true envelope, so they are rejected without it rather than
+// silently returning the whole array.
+func ParseContainerPage(q url.Values) (ContainerPage, error) {
+	var page ContainerPage
+	if q.Get("summary") != "true" {
+		for _, key := range []string{"sort", "order", "offset", "limit"} {
+			if q.Has(key) {
+				return page, &FieldError{key, "requires summary=true"}
+			}
+		}
+		return page, nil
+	}
+	page.Sort = q.Get("sort")
+	if err := SortContainerSummaries(nil, page.Sort, false); err != nil {
+		return page, err
+	}
+	switch q.Get("order") {
+	case "asc":
+	case "desc":
+		page.Desc = true
+	case "":
+		// 정렬 키가 없으면 최신 생성 순
+		page.Desc = page.Sort == ""
+	default:
+		return page, &FieldError{"order", "must be asc or desc"}
+	}
+	for _, f := range []struct {
+		key string
+		dst *int
+	}{{"offset", &page.Offset}, {"limit", &page.Limit}} {
+		v := q.Get(f.key)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return page, &FieldError{f.key, "must be a non-negative integer"}
+		}
+		*f.dst = n
+	}
+	return page, nil
+}
+
+// SortContainerSummaries sorts by name, image, state or created (default
+// newest first).
+func SortContainerSummaries(items []ContainerSummary, key string, desc bool) error {
+	var less func(a, b ContainerSummary) bool
+	switch key {
+	case "name":
+		less = func(a, b ContainerSummary) bool { return a.Name < b.Name }
+	case "image":
+		less = func(a, b ContainerSummary) bool { return a.Image < b.Image }
+	case "state", "status":
+		less = func(a, b ContainerSummary) bool { return a.State < b.State }
+	case "created", "":
+		less = func(a, b ContainerSummary) bool { return a.Created.Before(b.Created) }
+	default:
+		return &FieldError{"sort", "must be name, image, state or created"}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if desc {
+			return less(items[j], items[i])
+		}
+		return less(items[i], items[j])
+	})
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseContainerPage(t *testing.T) {
+	tests := []struct {
+		query     string
+		want      ContainerPage
+		wantField string
+	}{
+		{query: "", want: ContainerPage{}},
+		{query: "all=true", want: ContainerPage{}},
+		{query: "limit=10", wantField: "limit"},
+		{query: "sort=name", wantField: "sort"},
+		{query: "summary=true", want: ContainerPage{Desc: true}},
+		{query: "summary=true&sort=name", want: ContainerPage{Sort: "name"}},
+		{query: "summary=true&sort=name&order=desc&offset=5&limit=20", want: ContainerPage{Sort: "name", Desc: true, Offset: 5, Limit: 20}},
+		{query: "summary=true&order=asc", want: ContainerPage{}},
+		{query: "summary=true&order=up", wantField: "order"},
+		{query: "summary=true&sort=size", wantField: "sort"},
+		{query: "summary=true&limit=abc", wantField: "limit"},
+		{query: "summary=true&offset=-5", wantField: "offset"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseContainerPage(q)
+			if tt.wantField != "" {
+				fe, ok := err.(*FieldError)
+				if !ok || fe.Field != tt.wantField {
+					t.Fatalf("ParseContainerPage(%q) error = %v, want a %s field error", tt.query, err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseContainerPage(%q) error: %v", tt.query, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseContainerPage(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gorilla/mux"
+)
+
+// GET /go/containers/{id}/top?ps_args=aux
+// Lists the container's processes. rows holds one object per process keyed
+// by the ps column titles; ps_args is passed to ps on the host (default -ef).
+func ContainerTopHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var args []string
+	if psArgs := strings.TrimSpace(r.URL.Query().Get("ps_args")); psArgs != "" {
+		args = []string{psArgs}
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	top, err := cli.ContainerTop(ctx, id, args)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	rows := make([]map[string]string, 0, len(top.Processes))
+	for _, p := range top.Processes {
+		row := make(map[string]string, len(top.Titles))
+		for i, title := range top.Titles {
+			if i < len(p) {
+				row[title] = p[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"titles":    top.Titles,
+		"processes": top.Processes,
+		"rows":      rows,
+		"count":     len(rows),
+	})
+}
+
+// GET /go/containers/{id}/wait?condition=not-running|next-exit|removed&timeout=60&stream=true
+// Blocks until the container reaches condition and reports its exit code.
+// timeout (seconds, default none) answers 408 when it expires. stream=true
+// answers with SSE instead: "waiting" with the current state, then "exit"
+// or "error".
+func ContainerWaitHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	q := r.URL.Query()
+	condition := container.WaitCondition(q.Get("condition"))
+	switch condition {
+	case "":
+		condition = container.WaitConditionNotRunning
+	case container.WaitConditionNotRunning, container.WaitConditionNextExit, container.WaitConditionRemoved:
+	default:
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "condition must be not-running, next-exit or removed", Field: "condition"})
+		return
+	}
+	ctx := r.Context()
+	if t := q.Get("timeout"); t != "" {
+		secs, err := strconv.Atoi(t)
+		if err != nil || secs <= 0 {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "timeout must be a positive number of seconds", Field: "timeout"})
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(secs)*time.Second)
+		defer cancel()
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	info, err := cli.ContainerInspect(ctx, id)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	// 대기 등록 후에 종료돼도 놓치지 않도록 inspect 다음 바로 wait 호출
+	statusCh, errCh := cli.ContainerWait(ctx, id, condition)
+
+	if q.Get("stream") != "true" {
+		ClearDeadlines(w)
+		select {
+		case st := <-statusCh:
+			WriteJSON(w, http.StatusOK, NewWaitResult(id, condition, st))
+		case err := <-errCh:
+			if ctx.Err() == context.DeadlineExceeded {
+				WriteJSON(w, http.StatusRequestTimeout, ErrorResponse{Error: "timed out waiting for container", Field: "timeout"})
+				return
+			}
+			WriteDockerError(w, err)
+		}
+		return
+	}
+
+	sse, err := NewSSEStream(w)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer sse.Close()
+	sse.KeepAlive(15 * time.Second)
+
+	_ = sse.Send("waiting", map[string]string{"id": id, "condition": string(condition), "state": info.State.Status})
+	select {
+	case st := <-statusCh:
+		_ = sse.Send("exit", NewWaitResult(id, condition, st))
+	case err := <-errCh:
+		msg := err.Error()
+		if ctx.Err() == context.DeadlineExceeded {
+			msg = "timed out waiting for container"
+		}
+		_ = sse.Send("error", map[string]string{"error": msg})
+	}
+}
+
+// NewWaitResult flattens a wait response.
+func NewWaitResult(id string, condition container.WaitCondition, st container.WaitResponse) WaitResult {
+	res := WaitResult{ID: id, Condition: string(condition), ExitCode: st.StatusCode}
+	if st.Error != nil {
+		res.Error = st.Error.Message
+	}
+	return res
+}
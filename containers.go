@@ -1,22 +1,41 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	dockerTypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	imageapi "github.com/docker/docker/api/types/image"
-	imageTypes "github.com/docker/docker/api/types/image"
-	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/gorilla/mux"
 
 )
 
+// GET /go/containers?all=true&status=running&project=demo&summary=true&sort=name&order=asc&offset=0&limit=20&size=true
+// Returns the SDK list (filters applied) as before. summary=true returns
+// sorted, paginated ContainerSummary items with the total before pagination;
+// sort, order, offset and limit without summary=true are a 400.
 func ListContainersHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts, err := ContainerListOptions(r)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	page, err := ParseContainerPage(q)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+
 	cli, err := NewDockerClient()
 	if err != nil {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
@@ -24,17 +43,37 @@ WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 	}
 	defer cli.Close()
 
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	// size=true면 데몬이 레이어 크기를 계산하느라 느려짐
+	timeout := 10 * time.Second
+	if opts.Size {
+		timeout = 60 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
 	defer cancel()
 
-	// Include stopped containers if all=true query provided
-	showAll := r.URL.Query().Get("all") == "true"
-	containers, err := cli.ContainerList(ctx, container.ListOptions{All: showAll})
+	containers, err := cli.ContainerList(ctx, opts)
 	if err != nil {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
-WriteJSON(w, http.StatusOK, containers)
+	if q.Get("summary") != "true" {
+		WriteJSON(w, http.StatusOK, containers)
+		return
+	}
+
+	items := make([]ContainerSummary, 0, len(containers))
+	for _, c := range containers {
+		items = append(items, NewContainerSummary(c))
+	}
+	_ = SortContainerSummaries(items, page.Sort, page.Desc) // 키는 ParseContainerPage에서 검증됨
+
+	total := len(items)
+	offset := min(page.Offset, total)
+	items = items[offset:]
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{"items": items, "total": total, "offset": offset, "limit": page.Limit})
 }
 
 func CreateContainerHandler(w http.ResponseWriter, r *http.Request) {
@@ -44,7 +83,13 @@ WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
 		return
 	}
 	if req.Image == "" {
-WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "image is required"})
+WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "image is required", Field: "image"})
+		return
+	}
+	// pull 전에 요청 전체를 검증해 잘못된 필드를 바로 알려줌
+	cfg, hostCfg, netCfg, err := BuildContainerConfig(req)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
 		return
 	}
 
@@ -55,65 +100,27 @@ WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 	}
 	defer cli.Close()
 
+	if r.URL.Query().Get("progress") == "true" {
+		createContainerWithProgress(w, r, cli, req, cfg, hostCfg, netCfg)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
 
-	// 1) 로컬에 이미지가 있는지 먼저 확인 (있으면 pull 스킵)
-	hasLocal := false
-	{
-		args := filters.NewArgs()
-		// reference 필터는 태그 포함 문자열로 매칭됩니다.
-		args.Add("reference", req.Image)
-		imgs, err := cli.ImageList(ctx, imageapi.ListOptions{Filters: args})
-		if err == nil && len(imgs) > 0 {
-			hasLocal = true
-		}
-		// 태그가 생략된 경우 :latest로도 한 번 더 확인
-		if !hasLocal && !ContainsColon(req.Image) {
-			args2 := filters.NewArgs()
-			args2.Add("reference", req.Image+":latest")
-			imgs2, err2 := cli.ImageList(ctx, imageapi.ListOptions{Filters: args2})
-			if err2 == nil && len(imgs2) > 0 {
-				hasLocal = true
-				req.Image = req.Image + ":latest"
-			}
-		}
-	}
-
-	// 2) 로컬에 없을 때만 pull 시도
-	if !hasLocal {
-		pullOpts := imageTypes.PullOptions{}
-		if req.Platform != "" { pullOpts.Platform = req.Platform }
-		rc, err := cli.ImagePull(ctx, req.Image, pullOpts)
-		if err != nil {
-			// 슬래시가 없는 단순 이름이면 library 프리픽스도 시도
-			if !ContainsSlash(req.Image) {
-				rc2, secondErr := cli.ImagePull(ctx, "docker.io/library/"+req.Image, pullOpts)
-				if secondErr != nil {
-WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-					return
-				}
-				defer rc2.Close()
-				_, _ = io.Copy(io.Discard, rc2)
-				// 성공적으로 pull했다면, 실제 사용 이미지명을 보정(:latest 자동)
-				if !ContainsColon(req.Image) {
-					req.Image = req.Image + ":latest"
-				}
-			} else {
+	// 로컬에 없을 때만 pull (진행 상황은 버림)
+	image, err := EnsureImage(ctx, cli, req.Image, req.Platform, nil)
+	if err != nil {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-				return
-			}
-		} else {
-			defer rc.Close()
-			_, _ = io.Copy(io.Discard, rc)
-		}
+		return
 	}
 
+	cfg.Image = image // pull 과정에서 :latest 등으로 보정됐을 수 있음
 	resp, err := cli.ContainerCreate(
 		ctx,
-		&container.Config{Image: req.Image, Cmd: req.Cmd, Env: req.Env, Tty: false},
-		&container.HostConfig{},
-		nil,
+		cfg,
+		hostCfg,
+		netCfg,
 		nil,
 		req.Name,
 	)
@@ -124,6 +131,33 @@ WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 WriteJSON(w, http.StatusCreated, resp)
 }
 
+// createContainerWithProgress serves POST /go/containers?progress=true: pull
+// progress is streamed as SSE "progress" events, followed by a "created"
+// event carrying the create response (or an "error" event).
+func createContainerWithProgress(w http.ResponseWriter, r *http.Request, cli *client.Client, req CreateContainerRequest, cfg *container.Config, hostCfg *container.HostConfig, netCfg *network.NetworkingConfig) {
+	sse, err := NewSSEStream(w)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	// 큰 이미지는 60초를 넘길 수 있으므로 클라이언트 연결이 유지되는 동안 계속 진행
+	ctx := r.Context()
+	image, err := EnsureImage(ctx, cli, req.Image, req.Platform, func(m jsonmessage.JSONMessage) error {
+		return sse.Send("progress", NewPullProgressEvent(m))
+	})
+	if err != nil {
+		_ = sse.Send("error", ErrorResponse{Error: err.Error()})
+		return
+	}
+	cfg.Image = image
+	resp, err := cli.ContainerCreate(ctx, cfg, hostCfg, netCfg, nil, req.Name)
+	if err != nil {
+		_ = sse.Send("error", ErrorResponse{Error: err.Error()})
+		return
+	}
+	_ = sse.Send("created", resp)
+}
+
 func StartContainerHandler(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
 	cli, err := NewDockerClient()
@@ -212,6 +246,11 @@ WriteJSON(w, http.StatusOK, info)
 }
 
 func ContainerLogsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("follow") == "true" {
+		ContainerLogsStreamHandler(w, r)
+		return
+	}
+
 	id := mux.Vars(r)["id"]
 	cli, err := NewDockerClient()
 	if err != nil { WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()}); return }
@@ -236,6 +275,123 @@ func ContainerLogsHandler(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(b)
 }
 
+// GET /go/containers/{id}/logs/stream?tail=200&since=...&until=...&stdout=true&stderr=true&follow=true
+// Streams demultiplexed log lines as SSE "log" events until the container
+// stops (an "end" event is sent) or the client disconnects.
+func ContainerLogsStreamHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	q := r.URL.Query()
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	// 클라이언트 연결이 끊기면 r.Context()가 취소되어 로그 스트림도 닫힘
+	ctx := r.Context()
+
+	info, err := cli.ContainerInspect(ctx, id)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tail := q.Get("tail")
+	if tail == "" {
+		tail = "200"
+	}
+	opts := container.LogsOptions{
+		ShowStdout: q.Get("stdout") != "false",
+		ShowStderr: q.Get("stderr") != "false",
+		Since:      q.Get("since"),
+		Until:      q.Get("until"),
+		Tail:       tail,
+		Timestamps: true,
+		Follow:     q.Get("follow") != "false",
+	}
+	rc, err := cli.ContainerLogs(ctx, id, opts)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer rc.Close()
+
+	sse, err := NewSSEStream(w)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer sse.Close()
+	sse.KeepAlive(15 * time.Second)
+
+	stdout := &LogLineWriter{Stream: "stdout", SSE: sse}
+	stderr := &LogLineWriter{Stream: "stderr", SSE: sse}
+	if info.Config != nil && info.Config.Tty {
+		// TTY 컨테이너는 멀티플렉싱 헤더 없이 raw 스트림으로 전달됨
+		_, err = io.Copy(stdout, rc)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, rc)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	_ = stdout.Flush()
+	_ = stderr.Flush()
+	if err != nil {
+		_ = sse.Send("error", ErrorResponse{Error: err.Error()})
+		return
+	}
+	_ = sse.Send("end", map[string]string{"id": id})
+}
+
+// LogLineWriter splits a log stream into lines and sends each as an SSE "log" event.
+type LogLineWriter struct {
+	Stream string
+	SSE    *SSEStream
+	buf    []byte
+}
+
+func (lw *LogLineWriter) Write(p []byte) (int, error) {
+	lw.buf = append(lw.buf, p...)
+	for {
+		i := bytes.IndexByte(lw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(lw.buf[:i])
+		lw.buf = lw.buf[i+1:]
+		if err := lw.SSE.Send("log", ParseLogLine(lw.Stream, line)); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends any trailing partial line.
+func (lw *LogLineWriter) Flush() error {
+	if len(lw.buf) == 0 {
+		return nil
+	}
+	line := string(lw.buf)
+	lw.buf = nil
+	return lw.SSE.Send("log", ParseLogLine(lw.Stream, line))
+}
+
+// ParseLogLine splits the RFC3339Nano timestamp that Docker prepends when
+// Timestamps is enabled from the rest of the line.
+func ParseLogLine(stream, line string) LogEvent {
+	line = strings.TrimSuffix(line, "\r")
+	ev := LogEvent{Stream: stream, Line: line}
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		if ts, err := time.Parse(time.RFC3339Nano, line[:i]); err == nil {
+			ev.Timestamp = ts
+			ev.Line = line[i+1:]
+		}
+	}
+	return ev
+}
+
 func ExecInContainerHandler(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
 	var req ExecRequest
@@ -285,30 +441,29 @@ func ContainerStatsHandler(w http.ResponseWriter, r *http.Request) {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()}); return
 	}
 
-	// Calculate CPU % roughly (Docker CLI style simplified)
-	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage - s.PreCPUStats.CPUUsage.TotalUsage)
-	systemDelta := float64(s.CPUStats.SystemUsage - s.PreCPUStats.SystemUsage)
-	cpuPercent := 0.0
-	if systemDelta > 0 && cpuDelta > 0 {
-		cpuPercent = (cpuDelta / systemDelta) * float64(len(s.CPUStats.CPUUsage.PercpuUsage)) * 100.0
-	}
-	memUsage := float64(s.MemoryStats.Usage)
-	memLimit := float64(s.MemoryStats.Limit)
-	memPercent := 0.0
-	if memLimit > 0 { memPercent = (memUsage / memLimit) * 100.0 }
+	// stream=false 이면 데몬이 두 번 샘플링하므로 PreCPUStats가 채워져 있음
+	sample := ComputeStatsSample(&s, nil)
 
 WriteJSON(w, http.StatusOK, map[string]any{
-		"cpu_percent": cpuPercent,
-		"mem_usage": memUsage,
-		"mem_limit": memLimit,
-		"mem_percent": memPercent,
+		"cpu_percent": sample.CPUPercent,
+		"online_cpus": sample.OnlineCPUs,
+		"mem_usage": sample.MemUsage,
+		"mem_limit": sample.MemLimit,
+		"mem_percent": sample.MemPercent,
 		"pids": s.PidsStats.Current,
 		"net": s.Networks,
 		"blkio": s.BlkioStats,
 	})
 }
 
+// POST /go/containers/prune?dry_run=true&until=24h&label=env=dev
+// dry_run=true only reports what would be removed.
 func PruneStoppedContainersHandler(w http.ResponseWriter, r *http.Request) {
+	args, err := PruneFilters(r, true)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
 	cli, err := NewDockerClient()
 	if err != nil {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
@@ -319,7 +474,17 @@ WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
 
-	report, err := cli.ContainersPrune(ctx, filters.Args{})
+	if r.URL.Query().Get("dry_run") == "true" {
+		preview, err := PreviewContainerPrune(ctx, cli, args)
+		if err != nil {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		WriteJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	report, err := cli.ContainersPrune(ctx, args)
 	if err != nil {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
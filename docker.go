@@ -2,9 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
 )
 
 func WriteJSON(w http.ResponseWriter, status int, v any) {
@@ -13,6 +21,21 @@ func WriteJSON(w http.ResponseWriter, status int, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
+// WriteDockerError maps daemon error classes (not found, conflict, invalid
+// parameter) to the matching HTTP status; anything else is a 500.
+func WriteDockerError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errdefs.IsNotFound(err):
+		status = http.StatusNotFound
+	case errdefs.IsConflict(err):
+		status = http.StatusConflict
+	case errdefs.IsInvalidParameter(err):
+		status = http.StatusBadRequest
+	}
+	WriteJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
 func NewDockerClient() (*client.Client, error) {
 	// Works with Docker Desktop on Windows/macOS/Linux using env or defaults
 	return client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
@@ -39,3 +62,90 @@ func ContainsSlash(ref string) bool {
 	}
 	return false
 }
+
+// QueryValues collects a query parameter given either repeatedly
+// (?type=a&type=b) or comma separated (?type=a,b).
+func QueryValues(r *http.Request, key string) []string {
+	return SplitValues(r.URL.Query()[key])
+}
+
+// SplitValues flattens repeated and comma separated values.
+func SplitValues(vals []string) []string {
+	var out []string
+	for _, v := range vals {
+		out = append(out, splitAndTrim(v, ",")...)
+	}
+	return out
+}
+
+// ParseKeyValues reads KEY=VALUE pairs, where each value is either one pair
+// or a JSON object such as {"KEY":"VALUE"}.
+func ParseKeyValues(vals []string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, v := range vals {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.HasPrefix(v, "{") {
+			var m map[string]string
+			if err := json.Unmarshal([]byte(v), &m); err != nil {
+				return nil, err
+			}
+			for k, val := range m {
+				out[k] = val
+			}
+			continue
+		}
+		k, val, ok := strings.Cut(v, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%q is not KEY=VALUE", v)
+		}
+		out[k] = val
+	}
+	return out, nil
+}
+
+// ContainerName returns a list entry's primary name without the leading
+// slash, falling back to the short ID.
+func ContainerName(c types.Container) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return c.ID[:12]
+}
+
+// ClearDeadlines lifts the server's read and write timeouts for a request
+// that uploads or produces large bodies (build contexts, archives).
+func ClearDeadlines(w http.ResponseWriter) {
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+}
+
+// DecodeJSONMessages reads a Docker progress stream (pull, build, load) and
+// passes each message to fn, which may be nil. An error reported inside the
+// stream is returned as an error.
+func DecodeJSONMessages(r io.Reader, fn func(jsonmessage.JSONMessage) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		var m jsonmessage.JSONMessage
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if m.Error != nil {
+			return m.Error
+		}
+		if m.ErrorMessage != "" {
+			return errors.New(m.ErrorMessage)
+		}
+		if fn != nil {
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+	}
+}
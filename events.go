@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// 대시보드 목록 갱신에 필요한 오브젝트 타입만 기본으로 전달
+var defaultEventTypes = []string{"container", "image", "volume", "network"}
+
+// GET /go/events?type=container&action=die&label=com.docker.compose.project=demo&since=10m (SSE)
+// type, action, label, container, image, volume and network may be repeated
+// or comma separated. Each Docker event is sent as an "event" message.
+func EventsHandler(w http.ResponseWriter, r *http.Request) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	args := filters.NewArgs()
+	types := QueryValues(r, "type")
+	if len(types) == 0 {
+		types = defaultEventTypes
+	}
+	for _, t := range types {
+		args.Add("type", t)
+	}
+	for _, a := range QueryValues(r, "action") {
+		args.Add("event", a)
+	}
+	for _, key := range []string{"label", "container", "image", "volume", "network"} {
+		for _, v := range QueryValues(r, key) {
+			args.Add(key, v)
+		}
+	}
+
+	q := r.URL.Query()
+	msgs, errs := cli.Events(r.Context(), events.ListOptions{
+		Since:   q.Get("since"),
+		Until:   q.Get("until"),
+		Filters: args,
+	})
+
+	sse, err := NewSSEStream(w)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer sse.Close()
+	sse.KeepAlive(15 * time.Second)
+
+	for {
+		select {
+		case m := <-msgs:
+			if err := sse.Send("event", NewDockerEvent(m)); err != nil {
+				return
+			}
+		case err := <-errs:
+			// until 이 지나 스트림이 정상 종료되면 io.EOF, 클라이언트 종료 시 context 에러
+			if r.Context().Err() != nil {
+				return
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				_ = sse.Send("error", ErrorResponse{Error: err.Error()})
+				return
+			}
+			_ = sse.Send("end", map[string]string{})
+			return
+		}
+	}
+}
+
+// NewDockerEvent flattens an SDK events.Message into the DTO sent to clients.
+func NewDockerEvent(m events.Message) DockerEvent {
+	return DockerEvent{
+		Type:       string(m.Type),
+		Action:     string(m.Action),
+		ID:         m.Actor.ID,
+		Name:       m.Actor.Attributes["name"],
+		Attributes: m.Actor.Attributes,
+		Scope:      m.Scope,
+		Time:       time.Unix(0, m.TimeNano),
+	}
+}
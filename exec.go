@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true // 브라우저가 아닌 클라이언트
+		}
+		for _, o := range allowedOrigins {
+			if o == origin {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// GET /go/containers/{id}/exec/ws?cmd=/bin/sh&cols=80&rows=24 (WebSocket)
+// Binary frames carry raw terminal bytes in both directions. Text frames carry
+// ExecSessionMessage JSON: "input" and "resize" from the client, "exit" and
+// "error" from the server.
+func ExecSessionHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	q := r.URL.Query()
+	cmd := q["cmd"]
+	if len(cmd) == 0 {
+		cmd = []string{"/bin/sh"}
+	}
+	cols, _ := strconv.ParseUint(q.Get("cols"), 10, 32)
+	rows, _ := strconv.ParseUint(q.Get("rows"), 10, 32)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	execCfg := container.ExecOptions{
+		Cmd:          cmd,
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          []string{"TERM=xterm-256color"},
+	}
+	if cols > 0 && rows > 0 {
+		execCfg.ConsoleSize = &[2]uint{uint(rows), uint(cols)}
+	}
+	execID, err := cli.ContainerExecCreate(ctx, id, execCfg)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	attach, err := cli.ContainerExecAttach(ctx, execID.ID, container.ExecStartOptions{Tty: true, ConsoleSize: execCfg.ConsoleSize})
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer attach.Close()
+
+	// Upgrade 이후에는 WriteJSON을 쓸 수 없음 (Upgrader가 직접 에러 응답을 작성)
+	ws, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	// 출력 펌프: 컨테이너 출력 -> WebSocket. 프로세스가 끝나면 종료 코드를 보내고 닫음.
+	outputDone := make(chan struct{})
+	go func() {
+		defer close(outputDone)
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := attach.Reader.Read(buf)
+			if n > 0 {
+				if werr := ws.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				break
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		msg := ExecSessionMessage{Type: "exit"}
+		inspectCtx, inspectCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer inspectCancel()
+		if info, err := cli.ContainerExecInspect(inspectCtx, execID.ID); err != nil {
+			msg = ExecSessionMessage{Type: "error", Data: err.Error()}
+		} else {
+			code := info.ExitCode
+			msg.ExitCode = &code
+		}
+		_ = ws.WriteJSON(msg)
+		_ = ws.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, "session ended"),
+			time.Now().Add(time.Second))
+	}()
+
+	go func() {
+		t := time.NewTicker(30 * time.Second)
+		defer t.Stop()
+		for {
+			select {
+			case <-outputDone:
+				return
+			case <-t.C:
+				if err := ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	// 입력 펌프: WebSocket -> 컨테이너 stdin / resize
+	for {
+		mt, data, err := ws.ReadMessage()
+		if err != nil {
+			break
+		}
+		if mt == websocket.TextMessage {
+			var msg ExecSessionMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				continue
+			}
+			if msg.Type == "resize" {
+				if msg.Cols > 0 && msg.Rows > 0 {
+					_ = cli.ContainerExecResize(ctx, execID.ID, container.ResizeOptions{Height: msg.Rows, Width: msg.Cols})
+				}
+				continue
+			}
+			if msg.Type != "input" {
+				continue
+			}
+			data = []byte(msg.Data)
+		}
+		if _, err := attach.Conn.Write(data); err != nil {
+			break
+		}
+	}
+
+	// 클라이언트가 먼저 끊은 경우 stdin을 닫아 셸이 종료되도록 함
+	_ = attach.CloseWrite()
+	select {
+	case <-outputDone:
+	case <-time.After(2 * time.Second):
+		cancel()
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// 업로드 가능한 이미지 아카이브 최대 크기
+const maxImageLoadSize = 8 << 30
+
+// GET /go/images/save?image=nginx:alpine&image=redis:7
+// Exports the images (repeated or comma separated) as one `docker save`
+// tar that can be loaded again offline.
+func SaveImagesHandler(w http.ResponseWriter, r *http.Request) {
+	images := QueryValues(r, "image")
+	if len(images) == 0 {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "at least one image is required", Field: "image"})
+		return
+	}
+	ClearDeadlines(w)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	rc, err := cli.ImageSave(r.Context(), images)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	defer rc.Close()
+
+	name := "images"
+	if len(images) == 1 {
+		name = strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(images[0])
+	}
+	WriteArchive(w, rc, name, "tar")
+}
+
+// POST /go/images/load
+// Imports a `docker save` archive (tar or tar.gz) sent as the multipart
+// "file" field or as the raw body, and reports the tags and IDs loaded.
+func LoadImagesHandler(w http.ResponseWriter, r *http.Request) {
+	ClearDeadlines(w)
+	r.Body = http.MaxBytesReader(w, r.Body, maxImageLoadSize)
+
+	var archive io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid multipart body: " + err.Error()})
+			return
+		}
+		// 수 GB 파일을 디스크에 임시 저장하지 않도록 파트를 그대로 스트리밍
+		archive = nil
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid multipart body: " + err.Error()})
+				return
+			}
+			if part.FormName() == "file" {
+				archive = part
+				break
+			}
+		}
+		if archive == nil {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "file is required", Field: "file"})
+			return
+		}
+	} else if r.ContentLength == 0 {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "image archive is required", Field: "file"})
+		return
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	resp, err := cli.ImageLoad(r.Context(), archive, true)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	tags, ids := []string{}, []string{}
+	err = DecodeJSONMessages(resp.Body, func(m jsonmessage.JSONMessage) error {
+		line := strings.TrimSpace(m.Stream)
+		if id, ok := strings.CutPrefix(line, "Loaded image ID: "); ok {
+			ids = append(ids, id)
+		} else if tag, ok := strings.CutPrefix(line, "Loaded image: "); ok {
+			tags = append(tags, tag)
+		}
+		return nil
+	})
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error(), Field: "file"})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{"status": "loaded", "tags": tags, "image_ids": ids})
+}
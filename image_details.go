@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	imageapi "github.com/docker/docker/api/types/image"
+	"github.com/docker/go-units"
+	"github.com/gorilla/mux"
+)
+
+// GET /go/images/{ref}/inspect
+// ref may contain slashes (ghcr.io/org/app:1.0). Returns a summary of the
+// image config; raw=true returns the full inspect document instead.
+func InspectImageHandler(w http.ResponseWriter, r *http.Request) {
+	ref := mux.Vars(r)["ref"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	img, _, err := cli.ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	if r.URL.Query().Get("raw") == "true" {
+		WriteJSON(w, http.StatusOK, img)
+		return
+	}
+
+	detail := ImageDetail{
+		ID:           img.ID,
+		RepoTags:     img.RepoTags,
+		RepoDigests:  img.RepoDigests,
+		Created:      img.Created,
+		Author:       img.Author,
+		Architecture: img.Architecture,
+		OS:           img.Os,
+		Size:         img.Size,
+		SizeHuman:    units.HumanSize(float64(img.Size)),
+		Layers:       len(img.RootFS.Layers),
+		ExposedPorts: []string{},
+		Volumes:      []string{},
+	}
+	if c := img.Config; c != nil {
+		detail.Env = c.Env
+		detail.Entrypoint = c.Entrypoint
+		detail.Cmd = c.Cmd
+		detail.WorkingDir = c.WorkingDir
+		detail.User = c.User
+		detail.Labels = c.Labels
+		detail.StopSignal = c.StopSignal
+		for p := range c.ExposedPorts {
+			detail.ExposedPorts = append(detail.ExposedPorts, string(p))
+		}
+		for v := range c.Volumes {
+			detail.Volumes = append(detail.Volumes, v)
+		}
+		sort.Strings(detail.ExposedPorts)
+		sort.Strings(detail.Volumes)
+	}
+	WriteJSON(w, http.StatusOK, detail)
+}
+
+// GET /go/images/{ref}/history
+// Lists the layers in Dockerfile order (base image first), each with the
+// instruction that created it and the size it added.
+func ImageHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ref := mux.Vars(r)["ref"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	history, err := cli.ImageHistory(ctx, ref)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+
+	layers := make([]ImageLayer, 0, len(history))
+	var total int64
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		total += h.Size
+		layers = append(layers, NewImageLayer(h))
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"ref":        ref,
+		"layers":     layers,
+		"count":      len(layers),
+		"size":       total,
+		"size_human": units.HumanSize(float64(total)),
+	})
+}
+
+// NewImageLayer flattens one history entry.
+func NewImageLayer(h imageapi.HistoryResponseItem) ImageLayer {
+	l := ImageLayer{
+		ID:          h.ID,
+		Created:     time.Unix(h.Created, 0).UTC(),
+		CreatedBy:   h.CreatedBy,
+		Instruction: HistoryInstruction(h.CreatedBy),
+		Size:        h.Size,
+		SizeHuman:   units.HumanSize(float64(h.Size)),
+		EmptyLayer:  h.Size == 0,
+		Comment:     h.Comment,
+		Tags:        h.Tags,
+	}
+	if l.ID == "<missing>" {
+		// 다른 곳에서 pull한 베이스 이미지 레이어는 ID가 없음
+		l.ID = ""
+	}
+	return l
+}
+
+// HistoryInstruction turns a history CreatedBy string back into the
+// Dockerfile instruction it came from. The classic builder records
+// "/bin/sh -c #(nop)  CMD [...]" for metadata and "/bin/sh -c cmd" for RUN;
+// BuildKit records "RUN /bin/sh -c cmd # buildkit".
+func HistoryInstruction(createdBy string) string {
+	s := strings.TrimSpace(createdBy)
+	s = strings.TrimSpace(strings.TrimSuffix(s, "# buildkit"))
+	if rest, ok := strings.CutPrefix(s, "/bin/sh -c #(nop)"); ok {
+		return strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(s, "/bin/sh -c "); ok {
+		return "RUN " + strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(s, "RUN /bin/sh -c "); ok {
+		return "RUN " + strings.TrimSpace(rest)
+	}
+	return s
+}
+
+// POST /go/images/{ref}/tag  {"target": "myapp:v1"}
+func TagImageHandler(w http.ResponseWriter, r *http.Request) {
+	ref := mux.Vars(r)["ref"]
+	var req TagImageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	if req.Target == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "target is required", Field: "target"})
+		return
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := cli.ImageTag(ctx, ref, req.Target); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "tagged", "ref": ref, "target": req.Target})
+}
+
+// POST /go/images/{ref}/untag
+// Removes the tag ref only. The last tag of an image is refused so this
+// never deletes an image; use DELETE /go/images/{ref} for that.
+func UntagImageHandler(w http.ResponseWriter, r *http.Request) {
+	ref := mux.Vars(r)["ref"]
+	tagged, err := ImageTagRef(ref)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	tag := reference.FamiliarString(tagged)
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	img, _, err := cli.ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	found := false
+	for _, t := range img.RepoTags {
+		if named, err := reference.ParseNormalizedNamed(t); err == nil && named.String() == tagged.String() {
+			found = true
+		}
+	}
+	if !found {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: ref + " is not a tag of this image", Field: "ref"})
+		return
+	}
+	if len(img.RepoTags) <= 1 {
+		WriteJSON(w, http.StatusConflict, ErrorResponse{Error: "this is the image's last tag; delete the image instead"})
+		return
+	}
+	if _, err := cli.ImageRemove(ctx, tag, imageapi.RemoveOptions{}); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "untagged", "ref": tag, "id": img.ID})
+}
+
+// ImageTagRef normalizes ref to a fully qualified name:tag, adding "latest"
+// when ref has no tag. A registry port such as localhost:5000/app is not
+// mistaken for a tag.
+func ImageTagRef(ref string) (reference.NamedTagged, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return nil, &FieldError{"ref", err.Error()}
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return nil, &FieldError{"ref", "must be a tag, not a digest"}
+	}
+	return reference.TagNameOnly(named).(reference.NamedTagged), nil
+}
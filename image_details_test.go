@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestImageTagRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{ref: "nginx", want: "docker.io/library/nginx:latest"},
+		{ref: "nginx:1.27", want: "docker.io/library/nginx:1.27"},
+		{ref: "org/app:v2", want: "docker.io/org/app:v2"},
+		{ref: "localhost:5000/app", want: "localhost:5000/app:latest"},
+		{ref: "localhost:5000/app:1.0", want: "localhost:5000/app:1.0"},
+		{ref: "ghcr.io/org/app", want: "ghcr.io/org/app:latest"},
+		{ref: "nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000", wantErr: true},
+		{ref: "Invalid:Ref", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ImageTagRef(tt.ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ImageTagRef(%q) = %v, want an error", tt.ref, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImageTagRef(%q) error: %v", tt.ref, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ImageTagRef(%q) = %q, want %q", tt.ref, got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,17 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
-	"os"
-	"os/exec"
+	"net/url"
 	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	imageapi "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/go-units"
 	"github.com/gorilla/mux"
 
 )
@@ -32,57 +37,86 @@ WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 WriteJSON(w, http.StatusOK, images)
 }
 
+// POST /go/images/build?stream=true
+// Builds req.Dockerfile with the SDK. The context is the server-side
+// directory context_path (honouring its .dockerignore) or, when empty, just
+// the Dockerfile. stream=true answers with the same SSE events as
+// /go/images/build/context.
 func BuildImageHandler(w http.ResponseWriter, r *http.Request) {
 	var req BuildImageRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
 		return
 	}
 	if req.ImageName == "" || req.Dockerfile == "" {
-WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "image_name and dockerfile are required"})
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "image_name and dockerfile are required"})
 		return
 	}
+	ClearDeadlines(w)
 
-	// Create temp Dockerfile
-	tmpFile, err := os.CreateTemp("", "Dockerfile_*.tmp")
+	buildReq, err := ParseBuildContextRequest(url.Values{"builder": {req.Builder}})
 	if err != nil {
-WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
 		return
 	}
-	tmpPath := tmpFile.Name()
-	_, _ = tmpFile.WriteString(req.Dockerfile)
-	_ = tmpFile.Close()
-	defer os.Remove(tmpPath)
+	buildReq.Tags = []string{req.ImageName}
+	buildReq.DockerfileContent = req.Dockerfile
+	buildReq.Platform = req.Platform
 
-	ctxPath := req.ContextPath
-	if ctxPath == "" {
-		ctxPath = "."
+	var dirCtx io.ReadCloser
+	if req.ContextPath != "" {
+		if dirCtx, err = DirContext(req.ContextPath); err != nil {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error(), Field: "context_path"})
+			return
+		}
+		defer dirCtx.Close()
+	}
+	var buildCtx io.Reader
+	if dirCtx != nil {
+		buildCtx = dirCtx
 	}
+	rc := ContextWithDockerfile(buildCtx, buildReq.Dockerfile, buildReq.DockerfileContent)
+	defer rc.Close()
 
-	// Use docker CLI for build to leverage local context and ignore rules
-	cmd := exec.Command("docker", "build", "-t", req.ImageName, "-f", tmpPath, ctxPath)
-	if req.Platform != "" {
-		cmd = exec.Command("docker", "build", "--platform", req.Platform, "-t", req.ImageName, "-f", tmpPath, ctxPath)
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
 	}
+	defer cli.Close()
 
-	output, err := cmd.CombinedOutput()
+	resp, err := cli.ImageBuild(r.Context(), rc, buildReq.ImageBuildOptions())
 	if err != nil {
-WriteJSON(w, http.StatusInternalServerError, map[string]any{
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer resp.Body.Close()
+
+	if r.URL.Query().Get("stream") == "true" {
+		streamBuild(w, resp.Body, buildReq)
+		return
+	}
+	res := collectBuild(resp.Body)
+	if res.err != nil {
+		WriteJSON(w, http.StatusInternalServerError, map[string]any{
 			"success": false,
-			"output":  string(output),
-			"error":   err.Error(),
+			"output":  res.output,
+			"error":   res.err.Error(),
+			"steps":   res.steps,
 		})
 		return
 	}
-
-WriteJSON(w, http.StatusOK, map[string]any{
-		"success": true,
-		"output":  string(output),
-		"image":   req.ImageName,
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"success":  true,
+		"output":   res.output,
+		"image":    req.ImageName,
+		"image_id": res.imageID,
+		"steps":    res.steps,
 	})
 }
 
 // DELETE /go/images/{ref}?force=true&pruneChildren=true
+// An unknown image is a 404; one still used by a container is a 409.
 func DeleteImageHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	ref := vars["ref"]
@@ -105,8 +139,196 @@ func DeleteImageHandler(w http.ResponseWriter, r *http.Request) {
 
 	_, err = cli.ImageRemove(ctx, ref, imageapi.RemoveOptions{Force: force, PruneChildren: pruneChildren})
 	if err != nil {
-		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		WriteDockerError(w, err)
 		return
 	}
 	WriteJSON(w, http.StatusOK, map[string]string{"status": "deleted", "ref": ref})
 }
+
+// POST /go/images/prune?all=true&until=24h&label=env=dev&dry_run=true
+// Removes dangling images, or every image without a container when all=true.
+func PruneImagesHandler(w http.ResponseWriter, r *http.Request) {
+	args, err := PruneFilters(r, true)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	if r.URL.Query().Get("all") == "true" {
+		args.Add("dangling", "false")
+	} else {
+		args.Add("dangling", "true")
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	defer cancel()
+
+	if r.URL.Query().Get("dry_run") == "true" {
+		preview, err := PreviewImagePrune(ctx, cli, args)
+		if err != nil {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		WriteJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	report, err := cli.ImagesPrune(ctx, args)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, report)
+}
+
+// POST /go/images/build-cache/prune?all=true&until=24h&keep_storage=10GB&dry_run=true
+// Removes unused build cache. keep_storage keeps up to that much cache.
+func PruneBuildCacheHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Has("label") {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "label is not supported for build cache", Field: "label"})
+		return
+	}
+	args, err := PruneFilters(r, true)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	opts := types.BuildCachePruneOptions{All: q.Get("all") == "true", Filters: args}
+	if ks := q.Get("keep_storage"); ks != "" {
+		if opts.KeepStorage, err = units.RAMInBytes(ks); err != nil {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error(), Field: "keep_storage"})
+			return
+		}
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	defer cancel()
+
+	if q.Get("dry_run") == "true" {
+		preview, err := PreviewBuildCachePrune(ctx, cli, opts)
+		if err != nil {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		WriteJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	report, err := cli.BuildCachePrune(ctx, opts)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, report)
+}
+
+// POST /go/images/pull  {"image": "nginx:alpine", "platform": "linux/arm64"} (SSE)
+// Streams per-layer "progress" events, then "done" with the pulled reference.
+func PullImageHandler(w http.ResponseWriter, r *http.Request) {
+	var req PullImageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	if req.Image == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "image is required", Field: "image"})
+		return
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	sse, err := NewSSEStream(w)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	image, err := PullImage(r.Context(), cli, req.Image, req.Platform, func(m jsonmessage.JSONMessage) error {
+		return sse.Send("progress", NewPullProgressEvent(m))
+	})
+	if err != nil {
+		_ = sse.Send("error", ErrorResponse{Error: err.Error()})
+		return
+	}
+	_ = sse.Send("done", map[string]string{"status": "pulled", "image": image})
+}
+
+// EnsureImage returns the reference to create a container from, pulling the
+// image only when no local copy exists. onMessage may be nil.
+func EnsureImage(ctx context.Context, cli *client.Client, image, platform string, onMessage func(jsonmessage.JSONMessage) error) (string, error) {
+	// 1) 로컬에 이미지가 있는지 먼저 확인 (있으면 pull 스킵)
+	args := filters.NewArgs()
+	// reference 필터는 태그 포함 문자열로 매칭됩니다.
+	args.Add("reference", image)
+	imgs, err := cli.ImageList(ctx, imageapi.ListOptions{Filters: args})
+	if err == nil && len(imgs) > 0 {
+		return image, nil
+	}
+	// 태그가 생략된 경우 :latest로도 한 번 더 확인
+	if !ContainsColon(image) {
+		args2 := filters.NewArgs()
+		args2.Add("reference", image+":latest")
+		imgs2, err2 := cli.ImageList(ctx, imageapi.ListOptions{Filters: args2})
+		if err2 == nil && len(imgs2) > 0 {
+			return image + ":latest", nil
+		}
+	}
+
+	// 2) 로컬에 없을 때만 pull 시도
+	return PullImage(ctx, cli, image, platform, onMessage)
+}
+
+// PullImage pulls image and passes every decoded progress message to
+// onMessage (may be nil). A bare name is retried as docker.io/library/<name>.
+func PullImage(ctx context.Context, cli *client.Client, image, platform string, onMessage func(jsonmessage.JSONMessage) error) (string, error) {
+	pullOpts := imageapi.PullOptions{Platform: platform}
+	rc, err := cli.ImagePull(ctx, image, pullOpts)
+	if err != nil {
+		// 슬래시가 없는 단순 이름이면 library 프리픽스도 시도
+		if ContainsSlash(image) {
+			return "", err
+		}
+		var secondErr error
+		rc, secondErr = cli.ImagePull(ctx, "docker.io/library/"+image, pullOpts)
+		if secondErr != nil {
+			return "", err
+		}
+	}
+	defer rc.Close()
+	if err := DecodeJSONMessages(rc, onMessage); err != nil {
+		return "", err
+	}
+	// 성공적으로 pull했다면, 실제 사용 이미지명을 보정(:latest 자동)
+	if !ContainsColon(image) {
+		image = image + ":latest"
+	}
+	return image, nil
+}
+
+// NewPullProgressEvent flattens a pull jsonmessage for the client.
+func NewPullProgressEvent(m jsonmessage.JSONMessage) PullProgressEvent {
+	ev := PullProgressEvent{ID: m.ID, Status: m.Status}
+	if m.Progress != nil {
+		ev.Current = m.Progress.Current
+		ev.Total = m.Progress.Total
+		if m.Progress.Total > 0 {
+			ev.Percent = float64(m.Progress.Current) / float64(m.Progress.Total) * 100.0
+		}
+	}
+	return ev
+}
@@ -23,6 +23,9 @@ func splitAndTrim(s, delim string) []string {
 	return result
 }
 
+// allowedOrigins holds the CORS origins; WebSocket upgrades are checked against it too.
+var allowedOrigins []string
+
 func main() {
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -44,6 +47,7 @@ func main() {
 	if len(origins) == 0 {
 		origins = []string{"http://localhost:3000", "http://127.0.0.1:3000"}
 	}
+	allowedOrigins = origins
 	c := cors.New(cors.Options{
 		AllowedOrigins:   origins,
 		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodOptions},
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/gorilla/mux"
+)
+
+// Network management handlers
+func ListNetworksHandler(w http.ResponseWriter, r *http.Request) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	networks, err := cli.NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, networks)
+}
+
+func InspectNetworkHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	nw, err := cli.NetworkInspect(ctx, id, network.InspectOptions{Verbose: r.URL.Query().Get("verbose") == "true"})
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, nw)
+}
+
+func CreateNetworkHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateNetworkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	if req.Name == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "name is required", Field: "name"})
+		return
+	}
+
+	opts := network.CreateOptions{
+		Driver:     req.Driver,
+		Internal:   req.Internal,
+		Attachable: req.Attachable,
+		Labels:     req.Labels,
+		Options:    req.Options,
+	}
+	if req.EnableIPv6 {
+		opts.EnableIPv6 = &req.EnableIPv6
+	}
+	if req.Subnet != "" {
+		if _, _, err := net.ParseCIDR(req.Subnet); err != nil {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "subnet must be CIDR, e.g. 172.28.0.0/16", Field: "subnet"})
+			return
+		}
+		if req.IPRange != "" {
+			if _, _, err := net.ParseCIDR(req.IPRange); err != nil {
+				WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "ip_range must be CIDR", Field: "ip_range"})
+				return
+			}
+		}
+		if req.Gateway != "" && net.ParseIP(req.Gateway) == nil {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "gateway must be an IP address", Field: "gateway"})
+			return
+		}
+		opts.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: req.Subnet, IPRange: req.IPRange, Gateway: req.Gateway}},
+		}
+	} else if req.Gateway != "" || req.IPRange != "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "gateway and ip_range require subnet", Field: "subnet"})
+		return
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	resp, err := cli.NetworkCreate(ctx, req.Name, opts)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusCreated, resp)
+}
+
+func DeleteNetworkHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := cli.NetworkRemove(ctx, id); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "deleted", "id": id})
+}
+
+// POST /go/networks/prune?dry_run=true&until=24h&label=env=dev
+func PruneNetworksHandler(w http.ResponseWriter, r *http.Request) {
+	args, err := PruneFilters(r, true)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	if r.URL.Query().Get("dry_run") == "true" {
+		preview, err := PreviewNetworkPrune(ctx, cli, args)
+		if err != nil {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		WriteJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	report, err := cli.NetworksPrune(ctx, args)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, report)
+}
+
+// POST /go/networks/{id}/connect  {"container": "web", "aliases": ["api"], "ipv4_address": "172.28.0.10"}
+func ConnectNetworkHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req NetworkConnectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	if req.Container == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "container is required", Field: "container"})
+		return
+	}
+	if req.IPv4Address != "" && net.ParseIP(req.IPv4Address).To4() == nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "ipv4_address must be an IPv4 address", Field: "ipv4_address"})
+		return
+	}
+	if ip := net.ParseIP(req.IPv6Address); req.IPv6Address != "" && (ip == nil || ip.To4() != nil) {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "ipv6_address must be an IPv6 address", Field: "ipv6_address"})
+		return
+	}
+
+	settings := &network.EndpointSettings{Aliases: req.Aliases}
+	// 고정 IP는 subnet이 지정된 사용자 정의 네트워크에서만 허용됨 (Docker가 검증)
+	if req.IPv4Address != "" || req.IPv6Address != "" {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: req.IPv4Address, IPv6Address: req.IPv6Address}
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := cli.NetworkConnect(ctx, id, req.Container, settings); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "connected", "id": id, "container": req.Container})
+}
+
+// POST /go/networks/{id}/disconnect  {"container": "web", "force": false}
+func DisconnectNetworkHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req NetworkDisconnectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	if req.Container == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "container is required", Field: "container"})
+		return
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := cli.NetworkDisconnect(ctx, id, req.Container, req.Force); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "disconnected", "id": id, "container": req.Container})
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	imageapi "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+)
+
+// PruneFilters builds prune filters from the query string:
+//   - until=24h | 2024-01-02T15:04:05Z | unix seconds (skipped when allowUntil is false)
+//   - label=key, label=key=value, label=!key=value (repeatable; ! negates)
+func PruneFilters(r *http.Request, allowUntil bool) (filters.Args, error) {
+	args := filters.NewArgs()
+	q := r.URL.Query()
+	if until := q.Get("until"); until != "" {
+		if !allowUntil {
+			return args, &FieldError{"until", "until is not supported for this prune"}
+		}
+		if _, err := ParseUntil(until, time.Now()); err != nil {
+			return args, &FieldError{"until", err.Error()}
+		}
+		args.Add("until", until)
+	}
+	for _, l := range QueryValues(r, "label") {
+		if neg, ok := strings.CutPrefix(l, "!"); ok {
+			args.Add("label!", neg)
+		} else {
+			args.Add("label", l)
+		}
+	}
+	return args, nil
+}
+
+// ParseUntil reads an until value the way the daemon does: a duration
+// relative to now, an RFC 3339 time or a unix timestamp.
+func ParseUntil(v string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	// 데몬(timetypes.ParseTimestamps)처럼 초와 나노초를 따로 읽음
+	s, frac, hasFrac := strings.Cut(v, ".")
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !hasFrac {
+		return time.Unix(sec, 0), nil
+	}
+	if len(frac) == 0 || len(frac) > 9 {
+		return time.Time{}, errors.New("invalid timestamp " + strconv.Quote(v))
+	}
+	nsec, err := strconv.ParseUint(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, int64(nsec)), nil
+}
+
+// pruneMatches applies the until and label filters of args to one object.
+func pruneMatches(args filters.Args, created time.Time, labels map[string]string) bool {
+	if until := args.Get("until"); len(until) > 0 {
+		t, err := ParseUntil(until[0], time.Now())
+		if err == nil && !created.Before(t) {
+			return false
+		}
+	}
+	if !args.MatchKVList("label", labels) {
+		return false
+	}
+	if args.Contains("label!") && args.MatchKVList("label!", labels) {
+		return false
+	}
+	return true
+}
+
+// NewPrunePreview totals the items of a dry run.
+func NewPrunePreview(items []PruneItem) PrunePreview {
+	p := PrunePreview{DryRun: true, Items: items, Count: len(items)}
+	if p.Items == nil {
+		p.Items = []PruneItem{}
+	}
+	for _, it := range items {
+		p.ReclaimableBytes += it.Size
+	}
+	p.ReclaimableHuman = units.HumanSize(float64(p.ReclaimableBytes))
+	return p
+}
+
+// PreviewContainerPrune lists the containers ContainersPrune would remove:
+// everything not running or paused that matches args. Size is the writable
+// layer.
+func PreviewContainerPrune(ctx context.Context, cli *client.Client, args filters.Args) (PrunePreview, error) {
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Size: true})
+	if err != nil {
+		return PrunePreview{}, err
+	}
+	var items []PruneItem
+	for _, c := range containers {
+		if c.State == "running" || c.State == "paused" || c.State == "restarting" {
+			continue
+		}
+		if !pruneMatches(args, time.Unix(c.Created, 0), c.Labels) {
+			continue
+		}
+		items = append(items, PruneItem{ID: c.ID[:12], Name: ContainerName(c), Detail: c.Status, Size: c.SizeRw})
+	}
+	return NewPrunePreview(items), nil
+}
+
+// PreviewVolumePrune lists the volumes VolumesPrune would remove: unused
+// anonymous volumes, or all unused volumes when args has all=true.
+func PreviewVolumePrune(ctx context.Context, cli *client.Client, args filters.Args) (PrunePreview, error) {
+	vols, err := VolumeUsageReport(ctx, cli)
+	if err != nil {
+		return PrunePreview{}, err
+	}
+	all := args.ExactMatch("all", "true") || args.ExactMatch("all", "1")
+	var items []PruneItem
+	for _, v := range vols {
+		if !v.Dangling || (!v.Anonymous && !all) {
+			continue
+		}
+		if !pruneMatches(args, time.Time{}, v.Labels) {
+			continue
+		}
+		size := v.Size
+		if size < 0 {
+			size = 0
+		}
+		items = append(items, PruneItem{ID: v.Name, Name: v.Name, Detail: v.Driver, Size: size})
+	}
+	return NewPrunePreview(items), nil
+}
+
+// PreviewNetworkPrune lists the local custom networks NetworksPrune would
+// remove: those without a running container attached.
+func PreviewNetworkPrune(ctx context.Context, cli *client.Client, args filters.Args) (PrunePreview, error) {
+	networks, err := cli.NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		return PrunePreview{}, err
+	}
+	// 실행 중인 컨테이너만 엔드포인트를 가짐
+	running, err := cli.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return PrunePreview{}, err
+	}
+	inUse := map[string]bool{}
+	for _, c := range running {
+		if c.NetworkSettings == nil {
+			continue
+		}
+		for _, ep := range c.NetworkSettings.Networks {
+			inUse[ep.NetworkID] = true
+		}
+	}
+	var items []PruneItem
+	for _, n := range networks {
+		if n.Name == "bridge" || n.Name == "host" || n.Name == "none" || n.Scope != "local" || n.Ingress {
+			continue
+		}
+		if inUse[n.ID] || !pruneMatches(args, n.Created, n.Labels) {
+			continue
+		}
+		items = append(items, PruneItem{ID: n.ID[:12], Name: n.Name, Detail: n.Driver})
+	}
+	return NewPrunePreview(items), nil
+}
+
+// PreviewImagePrune lists the images ImagesPrune would remove: untagged
+// images, or with dangling=false every image no container uses. Size counts
+// the whole image, so layers shared with kept images are overestimated.
+func PreviewImagePrune(ctx context.Context, cli *client.Client, args filters.Args) (PrunePreview, error) {
+	images, err := cli.ImageList(ctx, imageapi.ListOptions{})
+	if err != nil {
+		return PrunePreview{}, err
+	}
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return PrunePreview{}, err
+	}
+	used := map[string]bool{}
+	for _, c := range containers {
+		used[c.ImageID] = true
+	}
+	danglingOnly := !args.ExactMatch("dangling", "false")
+	var items []PruneItem
+	for _, img := range images {
+		tags := make([]string, 0, len(img.RepoTags))
+		for _, t := range img.RepoTags {
+			if t != "<none>:<none>" {
+				tags = append(tags, t)
+			}
+		}
+		if used[img.ID] || (danglingOnly && len(tags) > 0) {
+			continue
+		}
+		if !pruneMatches(args, time.Unix(img.Created, 0), img.Labels) {
+			continue
+		}
+		items = append(items, PruneItem{ID: shortImageID(img.ID), Name: strings.Join(tags, ", "), Size: img.Size})
+	}
+	return NewPrunePreview(items), nil
+}
+
+// PreviewBuildCachePrune lists unused build cache records. Without All,
+// shared records are kept like the daemon does. keep_storage is not
+// simulated.
+func PreviewBuildCachePrune(ctx context.Context, cli *client.Client, opts types.BuildCachePruneOptions) (PrunePreview, error) {
+	du, err := cli.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.BuildCacheObject}})
+	if err != nil {
+		return PrunePreview{}, err
+	}
+	var until time.Time
+	if v := opts.Filters.Get("until"); len(v) > 0 {
+		until, _ = ParseUntil(v[0], time.Now())
+	}
+	var items []PruneItem
+	for _, bc := range du.BuildCache {
+		if bc.InUse || (bc.Shared && !opts.All) {
+			continue
+		}
+		lastUsed := bc.CreatedAt
+		if bc.LastUsedAt != nil {
+			lastUsed = *bc.LastUsedAt
+		}
+		if !until.IsZero() && !lastUsed.Before(until) {
+			continue
+		}
+		items = append(items, PruneItem{ID: bc.ID, Name: bc.Description, Detail: bc.Type, Size: bc.Size})
+	}
+	return NewPrunePreview(items), nil
+}
+
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "24h", want: now.Add(-24 * time.Hour)},
+		{in: "1h30m", want: now.Add(-90 * time.Minute)},
+		{in: "2024-05-01T10:00:00Z", want: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{in: "2024-05-01T19:00:00+09:00", want: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{in: "2024-05-01", want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "1700000000", want: time.Unix(1700000000, 0)},
+		{in: "1700000000.75", want: time.Unix(1700000000, 750000000)},
+		{in: "1700000000.5", want: time.Unix(1700000000, 500000000)},
+		{in: "1700000000.000000001", want: time.Unix(1700000000, 1)},
+		{in: "1700000000.", wantErr: true},
+		{in: "1700000000.-5", wantErr: true},
+		{in: "yesterday", wantErr: true},
+		{in: "24", want: time.Unix(24, 0)}, // a bare number is a unix timestamp, not a duration
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseUntil(tt.in, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseUntil(%q) = %v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseUntil(%q) error: %v", tt.in, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseUntil(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneMatches(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	labels := map[string]string{"env": "dev", "team": "web"}
+	tests := []struct {
+		name    string
+		args    filters.Args
+		created time.Time
+		labels  map[string]string
+		want    bool
+	}{
+		{"no filters", filters.NewArgs(), recent, nil, true},
+		{"until duration, older", filters.NewArgs(filters.Arg("until", "24h")), old, nil, true},
+		{"until duration, newer", filters.NewArgs(filters.Arg("until", "24h")), recent, nil, false},
+		{"until RFC3339, older", filters.NewArgs(filters.Arg("until", time.Now().Add(-24*time.Hour).UTC().Format(time.RFC3339))), old, nil, true},
+		{"until RFC3339, newer", filters.NewArgs(filters.Arg("until", time.Now().Add(-24*time.Hour).UTC().Format(time.RFC3339))), recent, nil, false},
+		{"label key", filters.NewArgs(filters.Arg("label", "env")), recent, labels, true},
+		{"label key=value", filters.NewArgs(filters.Arg("label", "env=dev")), recent, labels, true},
+		{"label other value", filters.NewArgs(filters.Arg("label", "env=prod")), recent, labels, false},
+		{"label missing", filters.NewArgs(filters.Arg("label", "env")), recent, nil, false},
+		{"all labels must match", filters.NewArgs(filters.Arg("label", "env=dev"), filters.Arg("label", "team=db")), recent, labels, false},
+		{"negated label present", filters.NewArgs(filters.Arg("label!", "team=web")), recent, labels, false},
+		{"negated label absent", filters.NewArgs(filters.Arg("label!", "team=db")), recent, labels, true},
+		{"until and label", filters.NewArgs(filters.Arg("until", "24h"), filters.Arg("label", "env=dev")), old, labels, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pruneMatches(tt.args, tt.created, tt.labels); got != tt.want {
+				t.Errorf("pruneMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,17 +16,44 @@ func routes() http.Handler {
 	api.HandleFunc("/containers/{id}/start", StartContainerHandler).Methods(http.MethodPost)
 	api.HandleFunc("/containers/{id}/stop", StopContainerHandler).Methods(http.MethodPost)
 	api.HandleFunc("/containers/{id}/restart", RestartContainerHandler).Methods(http.MethodPost)
+	api.HandleFunc("/containers/{id}/pause", PauseContainerHandler).Methods(http.MethodPost)
+	api.HandleFunc("/containers/{id}/unpause", UnpauseContainerHandler).Methods(http.MethodPost)
+	api.HandleFunc("/containers/{id}/kill", KillContainerHandler).Methods(http.MethodPost) // ?signal=SIGTERM
+	api.HandleFunc("/containers/{id}/rename", RenameContainerHandler).Methods(http.MethodPost)
+	api.HandleFunc("/containers/{id}/update", UpdateContainerHandler).Methods(http.MethodPost)
 	api.HandleFunc("/containers/{id}", DeleteContainerHandler).Methods(http.MethodDelete)
 	api.HandleFunc("/containers/{id}/inspect", InspectContainerHandler).Methods(http.MethodGet)
 	api.HandleFunc("/containers/{id}/logs", ContainerLogsHandler).Methods(http.MethodGet)
+	api.HandleFunc("/containers/{id}/logs/stream", ContainerLogsStreamHandler).Methods(http.MethodGet) // SSE
 	api.HandleFunc("/containers/{id}/exec", ExecInContainerHandler).Methods(http.MethodPost)
+	api.HandleFunc("/containers/{id}/exec/ws", ExecSessionHandler).Methods(http.MethodGet) // WebSocket TTY
 	api.HandleFunc("/containers/{id}/stats", ContainerStatsHandler).Methods(http.MethodGet)
+	api.HandleFunc("/containers/{id}/stats/stream", ContainerStatsStreamHandler).Methods(http.MethodGet) // SSE, ?history=minutes
+	api.HandleFunc("/containers/{id}/stats/history", ContainerStatsHistoryHandler).Methods(http.MethodGet)
+	api.HandleFunc("/containers/{id}/stat", ContainerStatPathHandler).Methods(http.MethodGet)         // ?path=
+	api.HandleFunc("/containers/{id}/download", DownloadContainerPathHandler).Methods(http.MethodGet) // ?path=&format=tar|zip|raw
+	api.HandleFunc("/containers/{id}/upload", UploadContainerFilesHandler).Methods(http.MethodPost)   // ?path=
+	api.HandleFunc("/containers/{id}/changes", ContainerChangesHandler).Methods(http.MethodGet)
+	api.HandleFunc("/containers/{id}/commit", CommitContainerHandler).Methods(http.MethodPost)
+	api.HandleFunc("/containers/{id}/top", ContainerTopHandler).Methods(http.MethodGet)   // ?ps_args=aux
+	api.HandleFunc("/containers/{id}/wait", ContainerWaitHandler).Methods(http.MethodGet) // ?condition=&timeout=&stream=true
 	api.HandleFunc("/containers/prune", PruneStoppedContainersHandler).Methods(http.MethodPost)
 	
 	// Image endpoints
 	api.HandleFunc("/images", ListImagesHandler).Methods(http.MethodGet)
 	api.HandleFunc("/images/build", BuildImageHandler).Methods(http.MethodPost)
-	api.HandleFunc("/images/{ref}", DeleteImageHandler).Methods(http.MethodDelete)
+	api.HandleFunc("/images/build/context", BuildImageFromContextHandler).Methods(http.MethodPost) // multipart or tar body
+	api.HandleFunc("/images/pull", PullImageHandler).Methods(http.MethodPost) // SSE progress
+	api.HandleFunc("/images/save", SaveImagesHandler).Methods(http.MethodGet)  // ?image=a&image=b, tar download
+	api.HandleFunc("/images/load", LoadImagesHandler).Methods(http.MethodPost) // multipart "file" or tar body
+	// {ref:.+}: 슬래시가 들어간 이미지 이름(ghcr.io/org/app:1.0)도 허용
+	api.HandleFunc("/images/{ref:.+}/inspect", InspectImageHandler).Methods(http.MethodGet)
+	api.HandleFunc("/images/{ref:.+}/history", ImageHistoryHandler).Methods(http.MethodGet)
+	api.HandleFunc("/images/{ref:.+}/tag", TagImageHandler).Methods(http.MethodPost)
+	api.HandleFunc("/images/{ref:.+}/untag", UntagImageHandler).Methods(http.MethodPost)
+	api.HandleFunc("/images/{ref:.+}", DeleteImageHandler).Methods(http.MethodDelete)
+	api.HandleFunc("/images/prune", PruneImagesHandler).Methods(http.MethodPost)                   // ?all=&until=&label=&dry_run=
+	api.HandleFunc("/images/build-cache/prune", PruneBuildCacheHandler).Methods(http.MethodPost) // ?all=&until=&keep_storage=&dry_run=
 
 	// Compose endpoints
 	api.HandleFunc("/compose/files", ComposeListFilesHandler).Methods(http.MethodGet) // ?recursive=true for all files
@@ -41,11 +68,44 @@ func routes() http.Handler {
 	// Volume endpoints
 	api.HandleFunc("/volumes", ListVolumesHandler).Methods(http.MethodGet)
 	api.HandleFunc("/volumes", CreateVolumeHandler).Methods(http.MethodPost)
+	api.HandleFunc("/volumes/usage", VolumeUsageHandler).Methods(http.MethodGet) // {name}보다 먼저 등록
 	api.HandleFunc("/volumes/{name}", InspectVolumeHandler).Methods(http.MethodGet)
 	api.HandleFunc("/volumes/{name}", DeleteVolumeHandler).Methods(http.MethodDelete)
 	api.HandleFunc("/volumes/prune", PruneVolumesHandler).Methods(http.MethodPost)
 	api.HandleFunc("/volumes/{name}/browse", BrowseVolumeHandler).Methods(http.MethodGet)
+	api.HandleFunc("/volumes/{name}/file", ReadVolumeFileHandler).Methods(http.MethodGet)          // ?path=&max_bytes=
+	api.HandleFunc("/volumes/{name}/download", DownloadVolumePathHandler).Methods(http.MethodGet)  // ?path=&format=tar|zip|raw
+	api.HandleFunc("/volumes/{name}/upload", UploadVolumeFilesHandler).Methods(http.MethodPost)    // ?path=
+	api.HandleFunc("/volumes/{name}/backup", BackupVolumeHandler).Methods(http.MethodPost)
+	api.HandleFunc("/volumes/{name}/restore", RestoreVolumeHandler).Methods(http.MethodPost)
+	api.HandleFunc("/volumes/{name}/clone", CloneVolumeHandler).Methods(http.MethodPost)
+	api.HandleFunc("/volumes/{name}/rename", RenameVolumeHandler).Methods(http.MethodPost)
+
+	// Volume backups
+	api.HandleFunc("/backups", ListBackupsHandler).Methods(http.MethodGet)            // ?volume=
+	api.HandleFunc("/backups/{id}", InspectBackupHandler).Methods(http.MethodGet)    // ?verify=true
+	api.HandleFunc("/backups/{id}", DeleteBackupHandler).Methods(http.MethodDelete)
 	
+	// Network endpoints
+	api.HandleFunc("/networks", ListNetworksHandler).Methods(http.MethodGet)
+	api.HandleFunc("/networks", CreateNetworkHandler).Methods(http.MethodPost)
+	api.HandleFunc("/networks/prune", PruneNetworksHandler).Methods(http.MethodPost)
+	api.HandleFunc("/networks/{id}", InspectNetworkHandler).Methods(http.MethodGet)
+	api.HandleFunc("/networks/{id}", DeleteNetworkHandler).Methods(http.MethodDelete)
+	api.HandleFunc("/networks/{id}/connect", ConnectNetworkHandler).Methods(http.MethodPost)
+	api.HandleFunc("/networks/{id}/disconnect", DisconnectNetworkHandler).Methods(http.MethodPost)
+
+	// Topology graph (?format=dot for Graphviz)
+	api.HandleFunc("/topology", TopologyHandler).Methods(http.MethodGet)
+
+	// Event stream (SSE)
+	api.HandleFunc("/events", EventsHandler).Methods(http.MethodGet)
+
+	// System overview
+	api.HandleFunc("/system/df", SystemDiskUsageHandler).Methods(http.MethodGet)
+	api.HandleFunc("/system/info", SystemInfoHandler).Methods(http.MethodGet)
+	api.HandleFunc("/system/version", SystemVersionHandler).Methods(http.MethodGet)
+
 	// File save endpoints for practice pages
 	api.HandleFunc("/api/save-compose", SaveComposeFileHandler).Methods(http.MethodPost)
 	api.HandleFunc("/api/save-nginx", SaveNginxFileHandler).Methods(http.MethodPost)
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// errSSEClosed is returned by Send once the stream has been closed.
+var errSSEClosed = errors.New("sse stream closed")
+
+// SSEStream writes Server-Sent Events to a long-lived response.
+// Writes are serialized so that a keep-alive goroutine can share the stream.
+type SSEStream struct {
+	mu     sync.Mutex
+	w      http.ResponseWriter
+	rc     *http.ResponseController
+	closed bool
+	done   chan struct{}
+}
+
+// NewSSEStream sends the event-stream headers and lifts the server's
+// WriteTimeout for this response so the stream can outlive it.
+func NewSSEStream(w http.ResponseWriter) (*SSEStream, error) {
+	rc := http.NewResponseController(w)
+	// Zero deadline = no deadline; without this the 60s WriteTimeout in main.go cuts the stream.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no") // nginx 프록시 버퍼링 방지
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return nil, err
+	}
+	return &SSEStream{w: w, rc: rc, done: make(chan struct{})}, nil
+}
+
+// Send writes one event with v encoded as JSON in the data field.
+func (s *SSEStream) Send(event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errSSEClosed
+	}
+	if event != "" {
+		if _, err := fmt.Fprintf(s.w, "event: %s\n", event); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(s.w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// KeepAlive writes a comment line every interval until the stream is
+// closed, which keeps proxies from dropping an idle stream.
+func (s *SSEStream) KeepAlive(interval time.Duration) {
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-t.C:
+				s.mu.Lock()
+				if s.closed {
+					s.mu.Unlock()
+					return
+				}
+				_, err := fmt.Fprint(s.w, ": ping\n\n")
+				if err == nil {
+					err = s.rc.Flush()
+				}
+				s.mu.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Close stops the keep-alive and makes later writes fail. Handlers must call
+// it before returning: once it returns nothing touches the ResponseWriter.
+func (s *SSEStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
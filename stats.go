@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gorilla/mux"
+)
+
+// 구독자가 모두 떠난 뒤에도 이 시간 동안은 수집을 계속해 히스토리를 채워 둠
+const statsIdleTimeout = 5 * time.Minute
+
+// StatsHistorySize returns how many samples (about one per second) are kept
+// per container; STATS_HISTORY_SECONDS overrides the 10 minute default.
+func StatsHistorySize() int {
+	if n, err := strconv.Atoi(os.Getenv("STATS_HISTORY_SECONDS")); err == nil && n > 0 {
+		return n
+	}
+	return 600
+}
+
+// CalculateCPUPercent uses the Docker CLI formula: the container's CPU time
+// delta over the host's, scaled by the number of online CPUs. OnlineCPUs is
+// reported on cgroup v2 hosts where PercpuUsage is empty.
+func CalculateCPUPercent(v *container.StatsResponse) float64 {
+	cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage) - float64(v.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(v.CPUStats.SystemUsage) - float64(v.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(v.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(v.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if systemDelta > 0 && cpuDelta > 0 {
+		return (cpuDelta / systemDelta) * onlineCPUs * 100.0
+	}
+	return 0
+}
+
+// CalculateMemUsageNoCache subtracts the inactive page cache from the usage,
+// as `docker stats` does (total_inactive_file on cgroup v1, inactive_file on v2).
+func CalculateMemUsageNoCache(mem container.MemoryStats) uint64 {
+	if v, ok := mem.Stats["total_inactive_file"]; ok && v < mem.Usage {
+		return mem.Usage - v
+	}
+	if v := mem.Stats["inactive_file"]; v < mem.Usage {
+		return mem.Usage - v
+	}
+	return mem.Usage
+}
+
+// ComputeStatsSample turns a raw stats frame into a StatsSample. prev is the
+// previous sample of the same container (nil for the first) and is only used
+// for the per-second network and block I/O rates.
+func ComputeStatsSample(v *container.StatsResponse, prev *StatsSample) StatsSample {
+	s := StatsSample{
+		Time:       v.Read,
+		CPUPercent: CalculateCPUPercent(v),
+		OnlineCPUs: v.CPUStats.OnlineCPUs,
+		MemUsage:   CalculateMemUsageNoCache(v.MemoryStats),
+		MemLimit:   v.MemoryStats.Limit,
+		Pids:       v.PidsStats.Current,
+	}
+	if s.OnlineCPUs == 0 {
+		s.OnlineCPUs = uint32(len(v.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if s.MemLimit > 0 {
+		s.MemPercent = float64(s.MemUsage) / float64(s.MemLimit) * 100.0
+	}
+	for _, n := range v.Networks {
+		s.NetRxBytes += n.RxBytes
+		s.NetTxBytes += n.TxBytes
+	}
+	for _, e := range v.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			s.BlkReadBytes += e.Value
+		case "write":
+			s.BlkWriteBytes += e.Value
+		}
+	}
+	if prev != nil {
+		if secs := s.Time.Sub(prev.Time).Seconds(); secs > 0 {
+			s.NetRxRate = counterRate(s.NetRxBytes, prev.NetRxBytes, secs)
+			s.NetTxRate = counterRate(s.NetTxBytes, prev.NetTxBytes, secs)
+			s.BlkReadRate = counterRate(s.BlkReadBytes, prev.BlkReadBytes, secs)
+			s.BlkWriteRate = counterRate(s.BlkWriteBytes, prev.BlkWriteBytes, secs)
+		}
+	}
+	return s
+}
+
+// counterRate returns the per-second increase, treating a counter reset as zero.
+func counterRate(cur, prev uint64, secs float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / secs
+}
+
+// StatsRing is a fixed-size buffer of the most recent samples.
+type StatsRing struct {
+	buf  []StatsSample
+	next int
+	full bool
+}
+
+func NewStatsRing(size int) *StatsRing {
+	return &StatsRing{buf: make([]StatsSample, size)}
+}
+
+func (r *StatsRing) Push(s StatsSample) {
+	r.buf[r.next] = s
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Since returns the samples taken after t, oldest first.
+func (r *StatsRing) Since(t time.Time) []StatsSample {
+	var ordered []StatsSample
+	if r.full {
+		ordered = append(ordered, r.buf[r.next:]...)
+	}
+	ordered = append(ordered, r.buf[:r.next]...)
+	out := make([]StatsSample, 0, len(ordered))
+	for _, s := range ordered {
+		if s.Time.After(t) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// statsCollector keeps one streaming ContainerStats call per container and
+// fans samples out to subscribers while recording them in the ring.
+type statsCollector struct {
+	id        string
+	mu        sync.Mutex
+	ring      *StatsRing
+	subs      map[chan StatsSample]struct{}
+	running   bool
+	idleSince time.Time
+}
+
+var statsHub = struct {
+	mu         sync.Mutex
+	collectors map[string]*statsCollector
+}{collectors: map[string]*statsCollector{}}
+
+// statsSubscribe subscribes to the collector of id, creating it if needed.
+// The hub lock is held across Subscribe; run re-checks for subscribers under
+// the same lock before dropping an idle collector (see stopIdle).
+func statsSubscribe(id string) (*statsCollector, <-chan StatsSample, func()) {
+	statsHub.mu.Lock()
+	defer statsHub.mu.Unlock()
+	c, ok := statsHub.collectors[id]
+	if !ok {
+		c = &statsCollector{id: id, ring: NewStatsRing(StatsHistorySize()), subs: map[chan StatsSample]struct{}{}}
+		statsHub.collectors[id] = c
+	}
+	ch, unsubscribe := c.Subscribe()
+	return c, ch, unsubscribe
+}
+
+// statsCollectorLookup returns the live collector of id, or nil.
+func statsCollectorLookup(id string) *statsCollector {
+	statsHub.mu.Lock()
+	defer statsHub.mu.Unlock()
+	return statsHub.collectors[id]
+}
+
+// History returns the recorded samples newer than since.
+func (c *statsCollector) History(since time.Time) []StatsSample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ring.Since(since)
+}
+
+// Subscribe registers a new listener, starting collection if needed. The
+// channel is closed when collection stops (e.g. the container exits).
+// Callers go through statsSubscribe.
+func (c *statsCollector) Subscribe() (<-chan StatsSample, func()) {
+	ch := make(chan StatsSample, 16)
+	c.mu.Lock()
+	c.subs[ch] = struct{}{}
+	if !c.running {
+		c.running = true
+		go c.run()
+	}
+	c.mu.Unlock()
+
+	unsubscribe := func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := c.subs[ch]; ok {
+			delete(c.subs, ch)
+			close(ch)
+		}
+		if len(c.subs) == 0 {
+			c.idleSince = time.Now()
+		}
+	}
+	return ch, unsubscribe
+}
+
+// run collects until the container stops or nobody has subscribed for
+// statsIdleTimeout, then drops the collector and its history from the hub.
+func (c *statsCollector) run() {
+	defer func() {
+		statsHub.mu.Lock()
+		defer statsHub.mu.Unlock()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.running = false
+		for ch := range c.subs {
+			close(ch)
+		}
+		c.subs = map[chan StatsSample]struct{}{}
+		if statsHub.collectors[c.id] == c {
+			delete(statsHub.collectors, c.id)
+		}
+	}()
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rc, err := cli.ContainerStats(ctx, c.id, true)
+	if err != nil {
+		return
+	}
+	defer rc.Body.Close()
+
+	dec := json.NewDecoder(rc.Body)
+	var prev *StatsSample
+	for {
+		var v container.StatsResponse
+		if err := dec.Decode(&v); err != nil {
+			return
+		}
+		// 멈춘(stopped) 컨테이너는 Read 시각이 비어 있는 프레임을 보냄
+		if v.Read.IsZero() {
+			return
+		}
+		s := ComputeStatsSample(&v, prev)
+		prev = &s
+
+		c.mu.Lock()
+		c.ring.Push(s)
+		for ch := range c.subs {
+			select {
+			case ch <- s:
+			default: // 느린 구독자는 샘플을 건너뜀
+			}
+		}
+		idle := len(c.subs) == 0 && time.Since(c.idleSince) > statsIdleTimeout
+		c.mu.Unlock()
+		if idle && c.stopIdle() {
+			return
+		}
+	}
+}
+
+// stopIdle drops c from the hub if it still has no subscribers. Both locks
+// are held, so statsSubscribe either joined c before (and c keeps running)
+// or will create a fresh collector.
+func (c *statsCollector) stopIdle() bool {
+	statsHub.mu.Lock()
+	defer statsHub.mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.subs) > 0 {
+		return false
+	}
+	c.running = false
+	if statsHub.collectors[c.id] == c {
+		delete(statsHub.collectors, c.id)
+	}
+	return true
+}
+
+// historyWindow reads a window in minutes from the query, defaulting to the full buffer.
+func historyWindow(r *http.Request, key string) time.Time {
+	if m, err := strconv.ParseFloat(r.URL.Query().Get(key), 64); err == nil && m >= 0 {
+		return time.Now().Add(-time.Duration(m * float64(time.Minute)))
+	}
+	return time.Time{}
+}
+
+// GET /go/containers/{id}/stats/stream?history=5 (SSE)
+// Sends a "history" event with the buffered samples of the last N minutes,
+// then a "stats" event per second, and "end" when the container stops.
+func ContainerStatsStreamHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	// 이름/짧은 ID로 요청해도 같은 버퍼를 쓰도록 전체 ID로 정규화
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	info, err := cli.ContainerInspect(ctx, id)
+	cancel()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c, ch, unsubscribe := statsSubscribe(info.ID)
+	defer unsubscribe()
+
+	sse, err := NewSSEStream(w)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer sse.Close()
+	sse.KeepAlive(15 * time.Second)
+
+	if err := sse.Send("history", c.History(historyWindow(r, "history"))); err != nil {
+		return
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case s, ok := <-ch:
+			if !ok {
+				_ = sse.Send("end", map[string]string{"id": info.ID})
+				return
+			}
+			if err := sse.Send("stats", s); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// GET /go/containers/{id}/stats/history?minutes=5
+// Only reads what is already buffered; samples are empty unless a stream has
+// been open within the last statsIdleTimeout.
+func ContainerStatsHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	info, err := cli.ContainerInspect(ctx, id)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	samples := []StatsSample{}
+	if c := statsCollectorLookup(info.ID); c != nil {
+		samples = c.History(historyWindow(r, "minutes"))
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{"id": info.ID, "samples": samples})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func statsFrame(total, preTotal, system, preSystem uint64, online uint32, percpu int) *container.StatsResponse {
+	v := &container.StatsResponse{}
+	v.CPUStats.CPUUsage.TotalUsage = total
+	v.CPUStats.SystemUsage = system
+	v.CPUStats.OnlineCPUs = online
+	v.CPUStats.CPUUsage.PercpuUsage = make([]uint64, percpu)
+	v.PreCPUStats.CPUUsage.TotalUsage = preTotal
+	v.PreCPUStats.SystemUsage = preSystem
+	return v
+}
+
+func TestCalculateCPUPercent(t *testing.T) {
+	tests := []struct {
+		name string
+		v    *container.StatsResponse
+		want float64
+	}{
+		{"zero deltas", statsFrame(500, 500, 1000, 1000, 4, 0), 0},
+		{"all zero", statsFrame(0, 0, 0, 0, 0, 0), 0},
+		{"online cpus", statsFrame(150, 100, 1200, 1000, 4, 0), 100},
+		{"percpu fallback", statsFrame(150, 100, 1200, 1000, 0, 2), 50},
+		{"counter reset", statsFrame(50, 100, 1200, 1000, 4, 0), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateCPUPercent(tt.v); got != tt.want {
+				t.Errorf("CalculateCPUPercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeStatsSampleRates(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := statsFrame(0, 0, 0, 0, 1, 0)
+	v.Read = t0
+	v.Networks = map[string]container.NetworkStats{"eth0": {RxBytes: 1000, TxBytes: 500}}
+
+	first := ComputeStatsSample(v, nil)
+	if first.NetRxRate != 0 || first.NetTxRate != 0 || first.CPUPercent != 0 {
+		t.Fatalf("first sample has rates: %+v", first)
+	}
+
+	v.Read = t0.Add(2 * time.Second)
+	v.Networks = map[string]container.NetworkStats{"eth0": {RxBytes: 3000, TxBytes: 100}}
+	second := ComputeStatsSample(v, &first)
+	if second.NetRxRate != 1000 {
+		t.Errorf("NetRxRate = %v, want 1000", second.NetRxRate)
+	}
+	if second.NetTxRate != 0 {
+		t.Errorf("NetTxRate after counter reset = %v, want 0", second.NetTxRate)
+	}
+}
+
+func TestStatsRing(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(i int) time.Time { return t0.Add(time.Duration(i) * time.Second) }
+	tests := []struct {
+		name   string
+		pushed int
+		since  time.Time
+		want   []int
+	}{
+		{"empty", 0, time.Time{}, []int{}},
+		{"partial", 2, time.Time{}, []int{1, 2}},
+		{"exactly full", 3, time.Time{}, []int{1, 2, 3}},
+		{"wraparound", 5, time.Time{}, []int{3, 4, 5}},
+		{"wraparound twice", 7, time.Time{}, []int{5, 6, 7}},
+		{"since", 5, at(3), []int{4, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewStatsRing(3)
+			for i := 1; i <= tt.pushed; i++ {
+				r.Push(StatsSample{Time: at(i)})
+			}
+			got := r.Since(tt.since)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Since() returned %d samples, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				if !got[i].Time.Equal(at(w)) {
+					t.Errorf("sample %d = %v, want %v", i, got[i].Time, at(w))
+				}
+			}
+		})
+	}
+}
+
+func TestStatsCollectorStopIdle(t *testing.T) {
+	c := &statsCollector{id: "stop-idle-test", ring: NewStatsRing(1), subs: map[chan StatsSample]struct{}{}, running: true}
+	statsHub.mu.Lock()
+	statsHub.collectors[c.id] = c
+	statsHub.mu.Unlock()
+
+	// 유휴 판단 직후 구독자가 붙은 경우
+	ch := make(chan StatsSample, 1)
+	c.subs[ch] = struct{}{}
+	if c.stopIdle() {
+		t.Fatal("stopIdle() = true with a subscriber")
+	}
+	if statsCollectorLookup(c.id) != c {
+		t.Fatal("collector with a subscriber was dropped from the hub")
+	}
+
+	delete(c.subs, ch)
+	if !c.stopIdle() {
+		t.Fatal("stopIdle() = false without subscribers")
+	}
+	if statsCollectorLookup(c.id) != nil {
+		t.Error("idle collector is still in the hub")
+	}
+	if c.running {
+		t.Error("idle collector is still marked running")
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-units"
+)
+
+// GET /go/system/df
+// Disk usage per object type with the share that a prune could reclaim,
+// computed the same way as `docker system df`.
+func SystemDiskUsageHandler(w http.ResponseWriter, r *http.Request) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	// 볼륨 크기 계산 때문에 오래 걸릴 수 있음
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	defer cancel()
+
+	du, err := cli.DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, NewSystemDiskUsage(du))
+}
+
+// NewSystemDiskUsage summarizes a DiskUsage response. Image reclaimable is
+// the layer total minus what images in use hold on their own (Size minus
+// SharedSize), since layers shared with an unused image stay on disk.
+func NewSystemDiskUsage(du types.DiskUsage) SystemDiskUsage {
+	var images, containers, volumes, cache DiskUsageCategory
+
+	images.Size = du.LayersSize
+	var imagesInUse int64
+	for _, img := range du.Images {
+		images.Count++
+		if img.Containers > 0 {
+			images.Active++
+			if img.Size >= 0 && img.SharedSize >= 0 {
+				imagesInUse += img.Size - img.SharedSize
+			}
+		}
+	}
+	images.Reclaimable = max(images.Size-imagesInUse, 0)
+
+	for _, c := range du.Containers {
+		containers.Count++
+		containers.Size += c.SizeRw
+		if c.State == "running" || c.State == "paused" || c.State == "restarting" {
+			containers.Active++
+		} else {
+			containers.Reclaimable += c.SizeRw
+		}
+	}
+
+	for _, v := range du.Volumes {
+		volumes.Count++
+		if v.UsageData == nil {
+			continue
+		}
+		// 크기를 모르는(-1) 볼륨도 사용 중이면 active로 셈 (docker system df와 동일)
+		if v.UsageData.RefCount > 0 {
+			volumes.Active++
+		}
+		if v.UsageData.Size < 0 {
+			continue
+		}
+		volumes.Size += v.UsageData.Size
+		if v.UsageData.RefCount < 1 {
+			volumes.Reclaimable += v.UsageData.Size
+		}
+	}
+
+	for _, bc := range du.BuildCache {
+		cache.Count++
+		if bc.InUse {
+			cache.Active++
+		}
+		if bc.Shared {
+			continue // 공유 레코드는 다른 레코드 크기에 이미 포함됨
+		}
+		cache.Size += bc.Size
+		if !bc.InUse {
+			cache.Reclaimable += bc.Size
+		}
+	}
+
+	res := SystemDiskUsage{
+		Images:     images.withHuman(),
+		Containers: containers.withHuman(),
+		Volumes:    volumes.withHuman(),
+		BuildCache: cache.withHuman(),
+	}
+	for _, c := range []DiskUsageCategory{images, containers, volumes, cache} {
+		res.TotalSize += c.Size
+		res.TotalReclaimable += c.Reclaimable
+	}
+	res.TotalSizeHuman = units.HumanSize(float64(res.TotalSize))
+	res.TotalReclaimableHuman = units.HumanSize(float64(res.TotalReclaimable))
+	return res
+}
+
+func (c DiskUsageCategory) withHuman() DiskUsageCategory {
+	c.SizeHuman = units.HumanSize(float64(c.Size))
+	c.ReclaimableHuman = units.HumanSize(float64(c.Reclaimable))
+	return c
+}
+
+// GET /go/system/info
+func SystemInfoHandler(w http.ResponseWriter, r *http.Request) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	info, err := cli.Info(ctx)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, SystemInfo{
+		Name:              info.Name,
+		EngineVersion:     info.ServerVersion,
+		OperatingSystem:   info.OperatingSystem,
+		OSType:            info.OSType,
+		Architecture:      info.Architecture,
+		KernelVersion:     info.KernelVersion,
+		StorageDriver:     info.Driver,
+		LoggingDriver:     info.LoggingDriver,
+		CgroupDriver:      info.CgroupDriver,
+		CgroupVersion:     info.CgroupVersion,
+		CPUs:              info.NCPU,
+		MemTotal:          info.MemTotal,
+		MemTotalHuman:     units.HumanSize(float64(info.MemTotal)),
+		DockerRootDir:     info.DockerRootDir,
+		Containers:        info.Containers,
+		ContainersRunning: info.ContainersRunning,
+		ContainersPaused:  info.ContainersPaused,
+		ContainersStopped: info.ContainersStopped,
+		Images:            info.Images,
+		Warnings:          info.Warnings,
+	})
+}
+
+// GET /go/system/version
+func SystemVersionHandler(w http.ResponseWriter, r *http.Request) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	v, err := cli.ServerVersion(ctx)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"server":             v,
+		"client_api_version": cli.ClientVersion(),
+	})
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+)
+
+func TestNewSystemDiskUsageVolumes(t *testing.T) {
+	vol := func(size, refs int64) *volume.Volume {
+		return &volume.Volume{UsageData: &volume.UsageData{Size: size, RefCount: refs}}
+	}
+	du := types.DiskUsage{Volumes: []*volume.Volume{
+		vol(100, 1),
+		vol(50, 0),
+		vol(-1, 2), // 크기를 모르는 사용 중 볼륨
+		vol(-1, 0),
+		{},
+	}}
+	got := NewSystemDiskUsage(du).Volumes
+	if got.Count != 5 || got.Active != 2 || got.Size != 150 || got.Reclaimable != 50 {
+		t.Errorf("volumes = %+v, want count 5, active 2, size 150, reclaimable 50", got)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+)
+
+// GET /go/topology?all=true&format=dot
+// Returns containers, networks, published ports and mounts as a node/edge
+// graph (JSON by default, Graphviz DOT with format=dot).
+func TopologyHandler(w http.ResponseWriter, r *http.Request) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: r.URL.Query().Get("all") == "true"})
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	networks, err := cli.NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	// 별칭(alias)은 inspect 결과에만 있음. 실패하면 목록 데이터만 사용.
+	inspects := map[string]types.ContainerJSON{}
+	for _, c := range containers {
+		if info, err := cli.ContainerInspect(ctx, c.ID); err == nil {
+			inspects[c.ID] = info
+		}
+	}
+
+	topo := BuildTopology(containers, networks, inspects)
+	if r.URL.Query().Get("format") == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(TopologyDOT(topo)))
+		return
+	}
+	WriteJSON(w, http.StatusOK, topo)
+}
+
+// BuildTopology assembles the graph. Node IDs are prefixed with their type
+// ("container:", "network:", "volume:", "bind:") plus a single "host" node
+// that published ports hang off.
+func BuildTopology(containers []types.Container, networks []network.Summary, inspects map[string]types.ContainerJSON) Topology {
+	topo := Topology{Nodes: []TopologyNode{}, Edges: []TopologyEdge{}}
+	seen := map[string]bool{}
+	addNode := func(n TopologyNode) {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			topo.Nodes = append(topo.Nodes, n)
+		}
+	}
+
+	networkIDs := map[string]string{} // name -> ID
+	for _, n := range networks {
+		networkIDs[n.Name] = n.ID
+		addNode(TopologyNode{
+			ID:    "network:" + n.ID,
+			Type:  "network",
+			Label: n.Name,
+			Meta:  map[string]string{"driver": n.Driver, "scope": n.Scope, "internal": fmt.Sprint(n.Internal)},
+		})
+	}
+
+	for _, c := range containers {
+		name := ContainerName(c)
+		cid := "container:" + c.ID
+		addNode(TopologyNode{
+			ID:    cid,
+			Type:  "container",
+			Label: name,
+			Meta:  map[string]string{"image": c.Image, "state": c.State, "status": c.Status},
+		})
+
+		// Networks
+		endpoints := map[string]*network.EndpointSettings{}
+		if c.NetworkSettings != nil {
+			endpoints = c.NetworkSettings.Networks
+		}
+		if info, ok := inspects[c.ID]; ok && info.NetworkSettings != nil {
+			endpoints = info.NetworkSettings.Networks
+		}
+		netNames := make([]string, 0, len(endpoints))
+		for netName := range endpoints {
+			netNames = append(netNames, netName)
+		}
+		sort.Strings(netNames)
+		for _, netName := range netNames {
+			ep := endpoints[netName]
+			netID := ep.NetworkID
+			if netID == "" {
+				netID = networkIDs[netName]
+			}
+			if netID == "" {
+				// 목록 조회 이후 삭제된 네트워크 등: 가리킬 노드가 없으므로 생략
+				continue
+			}
+			// 목록 조회 이후 생성된 네트워크도 끊긴 엣지가 되지 않도록 노드를 추가
+			addNode(TopologyNode{ID: "network:" + netID, Type: "network", Label: netName})
+			meta := map[string]string{}
+			if ep.IPAddress != "" {
+				meta["ip"] = ep.IPAddress
+			}
+			if len(ep.Aliases) > 0 {
+				meta["aliases"] = strings.Join(ep.Aliases, ",")
+			}
+			topo.Edges = append(topo.Edges, TopologyEdge{From: cid, To: "network:" + netID, Type: "network", Label: ep.IPAddress, Meta: meta})
+		}
+
+		// Published ports
+		for _, p := range c.Ports {
+			if p.PublicPort == 0 {
+				continue
+			}
+			addNode(TopologyNode{ID: "host", Type: "host", Label: "host"})
+			hostIP := p.IP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+			label := fmt.Sprintf("%s:%d->%d/%s", hostIP, p.PublicPort, p.PrivatePort, p.Type)
+			topo.Edges = append(topo.Edges, TopologyEdge{From: "host", To: cid, Type: "port", Label: label})
+		}
+
+		// Mounts
+		for _, m := range c.Mounts {
+			var nodeID string
+			switch m.Type {
+			case mount.TypeVolume:
+				nodeID = "volume:" + m.Name
+				addNode(TopologyNode{ID: nodeID, Type: "volume", Label: m.Name, Meta: map[string]string{"driver": m.Driver}})
+			case mount.TypeBind:
+				nodeID = "bind:" + m.Source
+				addNode(TopologyNode{ID: nodeID, Type: "bind", Label: m.Source})
+			default:
+				continue
+			}
+			mode := "rw"
+			if !m.RW {
+				mode = "ro"
+			}
+			topo.Edges = append(topo.Edges, TopologyEdge{From: cid, To: nodeID, Type: "mount", Label: m.Destination + " (" + mode + ")"})
+		}
+	}
+	return topo
+}
+
+// TopologyDOT renders the graph in Graphviz DOT.
+func TopologyDOT(t Topology) string {
+	shapes := map[string]string{"container": "box", "network": "ellipse", "volume": "cylinder", "bind": "folder", "host": "house"}
+	var b strings.Builder
+	b.WriteString("graph docker {\n\trankdir=LR;\n")
+	for _, n := range t.Nodes {
+		fmt.Fprintf(&b, "\t%q [label=%q, shape=%s];\n", n.ID, n.Label, shapes[n.Type])
+	}
+	for _, e := range t.Edges {
+		fmt.Fprintf(&b, "\t%q -- %q [label=%q];\n", e.From, e.To, e.Label)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
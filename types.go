@@ -6,6 +6,7 @@ import (
 
 type ErrorResponse struct {
 	Error string `json:"error"`
+	Field string `json:"field,omitempty"` // invalid request field, if any
 }
 
 type CreateContainerRequest struct {
@@ -14,13 +15,113 @@ type CreateContainerRequest struct {
 	Cmd      []string `json:"cmd"`
 	Env      []string `json:"env"`
 	Platform string   `json:"platform"` // e.g., "linux/amd64" (optional)
+
+	// Optional container settings
+	Entrypoint     []string           `json:"entrypoint"`
+	WorkingDir     string             `json:"working_dir"`
+	User           string             `json:"user"` // user[:group]
+	Labels         map[string]string  `json:"labels"`
+	Ports          []PortBindingSpec  `json:"ports"`
+	Mounts         []MountSpec        `json:"mounts"`
+	Network        string             `json:"network"`
+	NetworkAliases []string           `json:"network_aliases"`
+	RestartPolicy  *RestartPolicySpec `json:"restart_policy"`
+	Memory         string             `json:"memory"` // e.g., "512m", "1g"
+	CPUs           float64            `json:"cpus"`   // e.g., 0.5
+	Healthcheck    *HealthcheckSpec   `json:"healthcheck"`
+}
+
+type PortBindingSpec struct {
+	ContainerPort int    `json:"container_port"`
+	HostPort      int    `json:"host_port"` // 0 = random host port
+	HostIP        string `json:"host_ip"`   // optional, e.g., "127.0.0.1"
+	Protocol      string `json:"protocol"`  // tcp (default), udp, sctp
+}
+
+type MountSpec struct {
+	Type     string `json:"type"`   // volume (default), bind, tmpfs
+	Source   string `json:"source"` // volume name or absolute host path
+	Target   string `json:"target"` // absolute path in the container
+	ReadOnly bool   `json:"read_only"`
+}
+
+type RestartPolicySpec struct {
+	Name       string `json:"name"`        // no, always, unless-stopped, on-failure
+	MaxRetries int    `json:"max_retries"` // on-failure only
+}
+
+type HealthcheckSpec struct {
+	Test        []string `json:"test"`         // ["CMD", ...], ["CMD-SHELL", "..."] or a plain shell command
+	Interval    string   `json:"interval"`     // e.g., "30s"
+	Timeout     string   `json:"timeout"`      // e.g., "5s"
+	StartPeriod string   `json:"start_period"` // e.g., "10s"
+	Retries     int      `json:"retries"`
 }
 
 type BuildImageRequest struct {
 	ImageName   string `json:"image_name"`
-	Dockerfile  string `json:"dockerfile"`      // Dockerfile content
-	ContextPath string `json:"context_path"`    // default "." (server-side path)
-	Platform    string `json:"platform"`        // optional, e.g., linux/amd64
+	Dockerfile  string `json:"dockerfile"`   // Dockerfile content
+	ContextPath string `json:"context_path"` // optional server-side directory; empty = Dockerfile only
+	Platform    string `json:"platform"`     // optional, e.g., linux/amd64
+	Builder     string `json:"builder"`      // buildkit or classic; empty = daemon default
+}
+
+// BuildContextRequest holds the options of an uploaded-context build.
+// It arrives as multipart form values or query parameters, not JSON.
+type BuildContextRequest struct {
+	Tags              []string          `json:"tags"`
+	Dockerfile        string            `json:"dockerfile"`         // path inside the context, default "Dockerfile"
+	DockerfileContent string            `json:"dockerfile_content"` // optional, written into the context at Dockerfile
+	BuildArgs         map[string]string `json:"build_args"`
+	Labels            map[string]string `json:"labels"`
+	Target            string            `json:"target"` // multi-stage target
+	NoCache           bool              `json:"no_cache"`
+	Pull              bool              `json:"pull"` // always pull base images
+	Platform          string            `json:"platform"`
+	Builder           string            `json:"builder"` // buildkit or classic; empty = daemon default
+}
+
+// BuildStep summarizes one Dockerfile instruction of a build.
+type BuildStep struct {
+	Number      int    `json:"number"`
+	Total       int    `json:"total"`
+	Stage       string `json:"stage,omitempty"` // BuildKit multi-stage name, e.g., "builder"
+	Instruction string `json:"instruction"`     // e.g., "RUN apk add curl"
+	Cached      bool   `json:"cached"`
+	LayerID     string `json:"layer_id,omitempty"` // classic builder only
+	Done        bool   `json:"done"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BuildEvent is one structured build progress event.
+// Type: step, step_done, log, pull, progress, done, error.
+type BuildEvent struct {
+	Type        string   `json:"type"`
+	Step        int      `json:"step,omitempty"`
+	TotalSteps  int      `json:"total_steps,omitempty"`
+	Stage       string   `json:"stage,omitempty"`
+	Instruction string   `json:"instruction,omitempty"`
+	Cached      bool     `json:"cached,omitempty"`
+	LayerID     string   `json:"layer_id,omitempty"`
+	Line        string   `json:"line,omitempty"`
+	Percent     float64  `json:"percent,omitempty"` // pull progress
+	ImageID     string   `json:"image_id,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+type PullImageRequest struct {
+	Image    string `json:"image"`
+	Platform string `json:"platform"` // optional, e.g., linux/arm64
+}
+
+// PullProgressEvent is one layer progress update while pulling an image.
+type PullProgressEvent struct {
+	ID      string  `json:"id,omitempty"` // layer ID
+	Status  string  `json:"status"`       // e.g., "Downloading", "Pull complete"
+	Current int64   `json:"current,omitempty"`
+	Total   int64   `json:"total,omitempty"`
+	Percent float64 `json:"percent,omitempty"`
 }
 
 type ComposeFileItem struct {
@@ -51,6 +152,99 @@ type ExecRequest struct {
 	Cmd []string `json:"cmd"`
 }
 
+// ExecSessionMessage is a JSON text frame on the exec WebSocket.
+type ExecSessionMessage struct {
+	Type     string `json:"type"`                // "input", "resize", "exit", "error"
+	Data     string `json:"data,omitempty"`      // input keystrokes or error message
+	Cols     uint   `json:"cols,omitempty"`      // resize
+	Rows     uint   `json:"rows,omitempty"`      // resize
+	ExitCode *int   `json:"exit_code,omitempty"` // exit
+}
+
+// StatsSample is one computed resource usage sample of a container.
+// Byte counters are cumulative; *_rate fields are bytes/sec since the previous sample.
+type StatsSample struct {
+	Time          time.Time `json:"time"`
+	CPUPercent    float64   `json:"cpu_percent"`
+	OnlineCPUs    uint32    `json:"online_cpus"`
+	MemUsage      uint64    `json:"mem_usage"` // page cache excluded
+	MemLimit      uint64    `json:"mem_limit"`
+	MemPercent    float64   `json:"mem_percent"`
+	Pids          uint64    `json:"pids"`
+	NetRxBytes    uint64    `json:"net_rx_bytes"`
+	NetTxBytes    uint64    `json:"net_tx_bytes"`
+	NetRxRate     float64   `json:"net_rx_rate"`
+	NetTxRate     float64   `json:"net_tx_rate"`
+	BlkReadBytes  uint64    `json:"blk_read_bytes"`
+	BlkWriteBytes uint64    `json:"blk_write_bytes"`
+	BlkReadRate   float64   `json:"blk_read_rate"`
+	BlkWriteRate  float64   `json:"blk_write_rate"`
+}
+
+// DockerEvent is a Docker engine event forwarded over /go/events.
+type DockerEvent struct {
+	Type       string            `json:"type"`   // container, image, volume, network
+	Action     string            `json:"action"` // e.g. start, die, pull, destroy
+	ID         string            `json:"id"`
+	Name       string            `json:"name,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Scope      string            `json:"scope,omitempty"`
+	Time       time.Time         `json:"time"`
+}
+
+// LogEvent is one container log line sent over the log stream.
+type LogEvent struct {
+	Stream    string    `json:"stream"` // "stdout" or "stderr"
+	Timestamp time.Time `json:"timestamp"`
+	Line      string    `json:"line"`
+}
+
+type CreateNetworkRequest struct {
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver"`   // default "bridge"
+	Subnet     string            `json:"subnet"`   // optional CIDR, e.g., "172.28.0.0/16"
+	IPRange    string            `json:"ip_range"` // optional CIDR within subnet
+	Gateway    string            `json:"gateway"`  // optional
+	Internal   bool              `json:"internal"`
+	Attachable bool              `json:"attachable"`
+	EnableIPv6 bool              `json:"enable_ipv6"`
+	Labels     map[string]string `json:"labels"`
+	Options    map[string]string `json:"options"` // driver options
+}
+
+type NetworkConnectRequest struct {
+	Container   string   `json:"container"`
+	Aliases     []string `json:"aliases"`
+	IPv4Address string   `json:"ipv4_address"` // static IP, needs a network with subnet
+	IPv6Address string   `json:"ipv6_address"`
+}
+
+type NetworkDisconnectRequest struct {
+	Container string `json:"container"`
+	Force     bool   `json:"force"`
+}
+
+// Topology is the node/edge graph returned by /go/topology.
+type Topology struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Edges []TopologyEdge `json:"edges"`
+}
+
+type TopologyNode struct {
+	ID    string            `json:"id"`   // e.g., "container:<id>", "network:<id>", "volume:<name>", "host"
+	Type  string            `json:"type"` // container, network, volume, bind, host
+	Label string            `json:"label"`
+	Meta  map[string]string `json:"meta,omitempty"`
+}
+
+type TopologyEdge struct {
+	From  string            `json:"from"`
+	To    string            `json:"to"`
+	Type  string            `json:"type"` // network, port, mount
+	Label string            `json:"label,omitempty"`
+	Meta  map[string]string `json:"meta,omitempty"`
+}
+
 // Volume file system browsing
 type VolumeFileInfo struct {
 	Name        string    `json:"name"`
@@ -60,5 +254,238 @@ type VolumeFileInfo struct {
 	Mode        string    `json:"mode"`
 	ModTime     time.Time `json:"mod_time"`
 	Permissions string    `json:"permissions"`
+	Type        string    `json:"type"` // file, dir, symlink, char, block, fifo, socket
+	LinkTarget  string    `json:"link_target,omitempty"`
+	UID         int       `json:"uid"`
+	GID         int       `json:"gid"`
+	Owner       string    `json:"owner"`
+	Group       string    `json:"group"`
 }
 
+
+// Volume backup / restore
+type VolumeBackup struct {
+	ID          string    `json:"id"` // 백업 파일 이름 (<volume>-<timestamp>-<random>.tar.gz)
+	Volume      string    `json:"volume"`
+	File        string    `json:"file"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	Compression string    `json:"compression"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RestoreVolumeRequest struct {
+	Backup string `json:"backup"`
+	Clear  bool   `json:"clear,omitempty"` // 기존 볼륨 내용을 먼저 비움
+}
+
+// Volume clone / rename
+type CloneVolumeRequest struct {
+	Target string `json:"target"`
+}
+
+type RenameVolumeRequest struct {
+	Name string `json:"name"`
+}
+
+// Volume usage report
+type VolumeUsage struct {
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver"`
+	Mountpoint string            `json:"mountpoint"`
+	CreatedAt  string            `json:"created_at,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Size       int64             `json:"size"` // -1: 드라이버가 크기를 제공하지 않음
+	SizeHuman  string            `json:"size_human,omitempty"`
+	RefCount   int64             `json:"ref_count"`
+	Consumers  []VolumeConsumer  `json:"consumers"`
+	Anonymous  bool              `json:"anonymous"`
+	Dangling   bool              `json:"dangling"`
+}
+
+type VolumeConsumer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Destination string `json:"destination"`
+	ReadOnly    bool   `json:"read_only"`
+}
+
+// Prune dry run
+type PruneItem struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"` // 컨테이너 상태, 볼륨/네트워크 드라이버
+	Size   int64  `json:"size"`
+}
+
+type PrunePreview struct {
+	DryRun           bool        `json:"dry_run"`
+	Items            []PruneItem `json:"items"`
+	Count            int         `json:"count"`
+	ReclaimableBytes int64       `json:"reclaimable_bytes"`
+	ReclaimableHuman string      `json:"reclaimable_human"`
+}
+
+// System
+type DiskUsageCategory struct {
+	Count            int    `json:"count"`
+	Active           int    `json:"active"`
+	Size             int64  `json:"size"`
+	SizeHuman        string `json:"size_human"`
+	Reclaimable      int64  `json:"reclaimable"`
+	ReclaimableHuman string `json:"reclaimable_human"`
+}
+
+type SystemDiskUsage struct {
+	Images                DiskUsageCategory `json:"images"`
+	Containers            DiskUsageCategory `json:"containers"`
+	Volumes               DiskUsageCategory `json:"volumes"`
+	BuildCache            DiskUsageCategory `json:"build_cache"`
+	TotalSize             int64             `json:"total_size"`
+	TotalSizeHuman        string            `json:"total_size_human"`
+	TotalReclaimable      int64             `json:"total_reclaimable"`
+	TotalReclaimableHuman string            `json:"total_reclaimable_human"`
+}
+
+type SystemInfo struct {
+	Name              string   `json:"name"`
+	EngineVersion     string   `json:"engine_version"`
+	OperatingSystem   string   `json:"operating_system"`
+	OSType            string   `json:"os_type"`
+	Architecture      string   `json:"architecture"`
+	KernelVersion     string   `json:"kernel_version"`
+	StorageDriver     string   `json:"storage_driver"`
+	LoggingDriver     string   `json:"logging_driver"`
+	CgroupDriver      string   `json:"cgroup_driver"`
+	CgroupVersion     string   `json:"cgroup_version"`
+	CPUs              int      `json:"cpus"`
+	MemTotal          int64    `json:"mem_total"`
+	MemTotalHuman     string   `json:"mem_total_human"`
+	DockerRootDir     string   `json:"docker_root_dir"`
+	Containers        int      `json:"containers"`
+	ContainersRunning int      `json:"containers_running"`
+	ContainersPaused  int      `json:"containers_paused"`
+	ContainersStopped int      `json:"containers_stopped"`
+	Images            int      `json:"images"`
+	Warnings          []string `json:"warnings,omitempty"`
+}
+
+// Image details
+type ImageDetail struct {
+	ID           string            `json:"id"`
+	RepoTags     []string          `json:"repo_tags"`
+	RepoDigests  []string          `json:"repo_digests"`
+	Created      string            `json:"created"`
+	Author       string            `json:"author,omitempty"`
+	Architecture string            `json:"architecture"`
+	OS           string            `json:"os"`
+	Size         int64             `json:"size"`
+	SizeHuman    string            `json:"size_human"`
+	Layers       int               `json:"layers"`
+	Env          []string          `json:"env"`
+	Entrypoint   []string          `json:"entrypoint"`
+	Cmd          []string          `json:"cmd"`
+	WorkingDir   string            `json:"working_dir"`
+	User         string            `json:"user"`
+	ExposedPorts []string          `json:"exposed_ports"` // "80/tcp"
+	Volumes      []string          `json:"volumes"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	StopSignal   string            `json:"stop_signal,omitempty"`
+}
+
+type ImageLayer struct {
+	ID          string    `json:"id,omitempty"`
+	Created     time.Time `json:"created"`
+	CreatedBy   string    `json:"created_by"`
+	Instruction string    `json:"instruction"` // 예: "RUN apk add curl", "CMD [\"nginx\"]"
+	Size        int64     `json:"size"`
+	SizeHuman   string    `json:"size_human"`
+	EmptyLayer  bool      `json:"empty_layer"` // 메타데이터만 바꾼 명령 (ENV, CMD 등)
+	Comment     string    `json:"comment,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+}
+
+type TagImageRequest struct {
+	Target string `json:"target"` // 예: "myapp:v1", "registry.local/team/app:1.0"
+}
+
+// Container filesystem changes / commit
+type ContainerChanges struct {
+	Added    []string    `json:"added"`
+	Modified []string    `json:"modified"`
+	Deleted  []string    `json:"deleted"`
+	Total    int         `json:"total"`
+	Tree     *ChangeNode `json:"tree"`
+}
+
+type ChangeNode struct {
+	Name     string        `json:"name"`
+	Path     string        `json:"path"`
+	Kind     string        `json:"kind,omitempty"` // added, modified, deleted (비어 있으면 하위에만 변경)
+	Children []*ChangeNode `json:"children,omitempty"`
+}
+
+type CommitContainerRequest struct {
+	Reference string        `json:"reference"` // 예: "myapp:step2" (비우면 태그 없는 이미지)
+	Comment   string        `json:"comment"`
+	Author    string        `json:"author"`
+	Changes   []string      `json:"changes"` // Dockerfile 명령, 예: "ENV MODE=dev", "EXPOSE 8080"
+	Pause     *bool         `json:"pause"`   // 기본 true
+	Config    *CommitConfig `json:"config"`
+}
+
+type CommitConfig struct {
+	Cmd          []string          `json:"cmd"`
+	Entrypoint   []string          `json:"entrypoint"`
+	Env          []string          `json:"env"`
+	Labels       map[string]string `json:"labels"`
+	ExposedPorts []string          `json:"exposed_ports"` // "8080/tcp"
+	WorkingDir   string            `json:"working_dir"`
+	User         string            `json:"user"`
+}
+
+// Container control
+type RenameContainerRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateContainerRequest struct {
+	Memory            string             `json:"memory"`             // "512m"
+	MemoryReservation string             `json:"memory_reservation"` // soft limit
+	MemorySwap        string             `json:"memory_swap"`        // memory+swap 합계, "-1"은 무제한
+	CPUs              float64            `json:"cpus"`
+	CPUShares         int64              `json:"cpu_shares"`
+	PidsLimit         *int64             `json:"pids_limit"`
+	RestartPolicy     *RestartPolicySpec `json:"restart_policy"`
+}
+
+// Container wait
+type WaitResult struct {
+	ID        string `json:"id"`
+	Condition string `json:"condition"`
+	ExitCode  int64  `json:"exit_code"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Container list summary
+type ContainerSummary struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Image          string            `json:"image"`
+	Command        string            `json:"command"`
+	State          string            `json:"state"`            // running, exited, paused ...
+	Status         string            `json:"status"`           // 예: "Up 3 hours (healthy)"
+	Uptime         string            `json:"uptime,omitempty"` // 예: "3 hours"
+	Health         string            `json:"health,omitempty"` // healthy, unhealthy, starting
+	Created        time.Time         `json:"created"`
+	Ports          []string          `json:"ports"` // 예: "0.0.0.0:8080->80/tcp"
+	Networks       []string          `json:"networks"`
+	Mounts         []string          `json:"mounts"` // "volume-or-host-path:/container/path"
+	Labels         map[string]string `json:"labels,omitempty"`
+	ComposeProject string            `json:"compose_project,omitempty"`
+	ComposeService string            `json:"compose_service,omitempty"`
+	SizeRw         int64             `json:"size_rw,omitempty"` // size=true일 때만
+	SizeRootFs     int64             `json:"size_root_fs,omitempty"`
+	SizeHuman      string            `json:"size_human,omitempty"`
+}
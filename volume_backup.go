@@ -0,0 +1,376 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/mount"
+	volumeapi "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/gorilla/mux"
+)
+
+// BackupBaseDir returns the directory backups are stored in, BACKUP_DIR or
+// ./backups by default, creating it if needed.
+func BackupBaseDir() (string, error) {
+	base := os.Getenv("BACKUP_DIR")
+	if base == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(wd, "backups")
+	}
+	if err := os.MkdirAll(base, 0o755); err != nil {
+		return "", err
+	}
+	return base, nil
+}
+
+// backupPaths resolves the archive and metadata files of a backup ID.
+func backupPaths(id string) (string, string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || !strings.HasSuffix(id, ".tar.gz") {
+		return "", "", &FieldError{"backup", "invalid backup id"}
+	}
+	base, err := BackupBaseDir()
+	if err != nil {
+		return "", "", err
+	}
+	p, err := SafeJoin(base, id)
+	if err != nil {
+		return "", "", &FieldError{"backup", err.Error()}
+	}
+	return p, p + ".json", nil
+}
+
+// readBackupMeta loads the sidecar of a backup. File is resolved against the
+// current BACKUP_DIR rather than taken from the sidecar, so backups keep
+// working after the directory is moved.
+func readBackupMeta(id string) (VolumeBackup, error) {
+	var meta VolumeBackup
+	archivePath, metaPath, err := backupPaths(id)
+	if err != nil {
+		return meta, err
+	}
+	b, err := os.ReadFile(metaPath)
+	if err != nil {
+		return meta, err
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return meta, err
+	}
+	meta.ID = id
+	meta.File = archivePath
+	return meta, nil
+}
+
+// POST /go/volumes/{name}/backup
+// Archives the whole volume as <name>-<timestamp>-<random>.tar.gz in
+// BACKUP_DIR with a JSON sidecar holding size and SHA-256. Paths in the
+// archive are relative to the volume root, so `tar -xzf` works on it as well.
+func BackupVolumeHandler(w http.ResponseWriter, r *http.Request) {
+	volumeName := mux.Vars(r)["name"]
+	ClearDeadlines(w)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx := r.Context()
+	if _, err := cli.VolumeInspect(ctx, volumeName); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+
+	created := time.Now().UTC()
+	// 같은 초에 만든 백업끼리 덮어쓰지 않도록 임의 접미사를 붙임
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	id := fmt.Sprintf("%s-%s-%x.tar.gz", volumeName, created.Format("20060102-150405"), suffix)
+	archivePath, metaPath, err := backupPaths(id)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	rc, _, err := CopyFromVolume(ctx, cli, volumeName, "/")
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(archivePath), id+".*.tmp")
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer os.Remove(tmp.Name()) // rename 이후에는 no-op
+
+	hash := sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(tmp, hash))
+	err = StripTarPrefix(gz, rc, path.Base(volumeMountPoint))
+	if err == nil {
+		err = gz.Close()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), archivePath)
+	}
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	st, err := os.Stat(archivePath)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	meta := VolumeBackup{
+		ID:          id,
+		Volume:      volumeName,
+		File:        archivePath,
+		Size:        st.Size(),
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		Compression: "gzip",
+		CreatedAt:   created,
+	}
+	b, _ := json.MarshalIndent(meta, "", "  ")
+	if err := os.WriteFile(metaPath, b, 0o644); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusCreated, meta)
+}
+
+// StripTarPrefix copies a tar stream, removing the top-level directory
+// prefix that CopyFromContainer puts in front of every entry.
+func StripTarPrefix(dst io.Writer, src io.Reader, prefix string) error {
+	tr := tar.NewReader(src)
+	tw := tar.NewWriter(dst)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(hdr.Name, prefix), "/")
+		if name == "" {
+			continue
+		}
+		hdr.Name = name
+		if hdr.Typeflag == tar.TypeLink {
+			// 하드링크 대상도 같은 접두사가 붙은 아카이브 내 경로
+			hdr.Linkname = strings.TrimPrefix(strings.TrimPrefix(hdr.Linkname, prefix), "/")
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// GET /go/backups?volume=name
+func ListBackupsHandler(w http.ResponseWriter, r *http.Request) {
+	base, err := BackupBaseDir()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	volume := r.URL.Query().Get("volume")
+	backups := []VolumeBackup{}
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if e.IsDir() || !ok {
+			continue
+		}
+		meta, err := readBackupMeta(id)
+		if err != nil || (volume != "" && meta.Volume != volume) {
+			continue
+		}
+		backups = append(backups, meta)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	WriteJSON(w, http.StatusOK, backups)
+}
+
+// GET /go/backups/{id}?verify=true
+// verify=true recomputes the checksum and reports whether it still matches.
+func InspectBackupHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	meta, err := readBackupMeta(id)
+	if err != nil {
+		writeBackupError(w, err)
+		return
+	}
+	if r.URL.Query().Get("verify") != "true" {
+		WriteJSON(w, http.StatusOK, meta)
+		return
+	}
+	sum, err := fileSHA256(meta.File)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{"backup": meta, "sha256": sum, "valid": sum == meta.SHA256})
+}
+
+// DELETE /go/backups/{id}
+func DeleteBackupHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	archivePath, metaPath, err := backupPaths(id)
+	if err != nil {
+		writeBackupError(w, err)
+		return
+	}
+	if err := os.Remove(archivePath); err != nil && !os.IsNotExist(err) {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := os.Remove(metaPath); err != nil {
+		writeBackupError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]string{"status": "deleted", "id": id})
+}
+
+// POST /go/volumes/{name}/restore  {"backup": "<id>", "clear": true}
+// Restores a backup into the volume, creating it when it does not exist.
+// clear=true empties an existing volume first. The checksum is verified
+// before anything is written.
+func RestoreVolumeHandler(w http.ResponseWriter, r *http.Request) {
+	volumeName := mux.Vars(r)["name"]
+	var req RestoreVolumeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	meta, err := readBackupMeta(req.Backup)
+	if err != nil {
+		writeBackupError(w, err)
+		return
+	}
+	ClearDeadlines(w)
+
+	sum, err := fileSHA256(meta.File)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if sum != meta.SHA256 {
+		WriteJSON(w, http.StatusConflict, ErrorResponse{Error: "backup checksum mismatch; archive is corrupted", Field: "backup"})
+		return
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx := r.Context()
+	created := false
+	if _, err := cli.VolumeInspect(ctx, volumeName); err != nil {
+		if !errdefs.IsNotFound(err) {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if _, err := cli.VolumeCreate(ctx, volumeapi.CreateOptions{Name: volumeName}); err != nil {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		created = true
+	} else if req.Clear {
+		if err := ClearVolume(ctx, cli, volumeName); err != nil {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	f, err := os.Open(meta.File)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := CopyToVolume(ctx, cli, volumeName, "/", gz); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{"status": "restored", "name": volumeName, "backup": meta.ID, "created": created})
+}
+
+// ClearVolume deletes everything inside the named volume.
+func ClearVolume(ctx context.Context, cli *client.Client, volumeName string) error {
+	_, err := RunVolumeHelper(ctx, cli,
+		[]mount.Mount{VolumeMount(volumeName, volumeMountPoint, false)},
+		[]string{"find", volumeMountPoint, "-mindepth", "1", "-delete"})
+	return err
+}
+
+func fileSHA256(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeBackupError maps lookup errors to 400/404/500.
+func writeBackupError(w http.ResponseWriter, err error) {
+	var fe *FieldError
+	switch {
+	case errors.As(err, &fe):
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+	case os.IsNotExist(err):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{Error: "backup not found"})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	volumeapi "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/gorilla/mux"
+)
+
+// POST /go/volumes/{name}/clone  {"target": "data-copy"}
+// Creates target with the source's driver, options and labels and copies the
+// contents with `cp -a`, which keeps ownership, permissions and mtimes.
+// Bind- or device-backed local volumes are refused with 400.
+func CloneVolumeHandler(w http.ResponseWriter, r *http.Request) {
+	source := mux.Vars(r)["name"]
+	var req CloneVolumeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	if req.Target == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "target is required", Field: "target"})
+		return
+	}
+	ClearDeadlines(w)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	vol, err := CloneVolume(r.Context(), cli, source, req.Target)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusCreated, vol)
+}
+
+// POST /go/volumes/{name}/rename  {"name": "new-name"}
+// Docker cannot rename volumes, so this clones into the new name and removes
+// the source. Refused while any container still references the source.
+func RenameVolumeHandler(w http.ResponseWriter, r *http.Request) {
+	source := mux.Vars(r)["name"]
+	var req RenameVolumeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "invalid JSON body"})
+		return
+	}
+	if req.Name == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "name is required", Field: "name"})
+		return
+	}
+	ClearDeadlines(w)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx := r.Context()
+	users, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: filters.NewArgs(filters.Arg("volume", source))})
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if len(users) > 0 {
+		var running, stopped []string
+		for _, c := range users {
+			name := ContainerName(c)
+			if c.State == "running" {
+				running = append(running, name)
+			} else {
+				stopped = append(stopped, name)
+			}
+		}
+		msg := "volume is mounted by running containers: " + strings.Join(running, ", ")
+		if len(running) == 0 {
+			// 정지된 컨테이너가 참조 중이면 원본 삭제가 실패하므로 미리 거절
+			msg = "volume is used by stopped containers, remove them first: " + strings.Join(stopped, ", ")
+		}
+		WriteJSON(w, http.StatusConflict, ErrorResponse{Error: msg})
+		return
+	}
+
+	vol, err := CloneVolume(ctx, cli, source, req.Name)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	if err := cli.VolumeRemove(ctx, source, false); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("copied to %s but failed to remove %s: %v", req.Name, source, err)})
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{"status": "renamed", "from": source, "name": req.Name, "volume": vol})
+}
+
+// CloneVolume creates target like source and copies its content across in a
+// helper container. target is removed again if the copy fails.
+func CloneVolume(ctx context.Context, cli *client.Client, source, target string) (volumeapi.Volume, error) {
+	src, err := cli.VolumeInspect(ctx, source)
+	if err != nil {
+		return volumeapi.Volume{}, err
+	}
+	if _, err := cli.VolumeInspect(ctx, target); err == nil {
+		return volumeapi.Volume{}, errdefs.Conflict(fmt.Errorf("volume %s already exists", target))
+	} else if !errdefs.IsNotFound(err) {
+		return volumeapi.Volume{}, err
+	}
+
+	opts, err := CloneVolumeOptions(src, target)
+	if err != nil {
+		return volumeapi.Volume{}, err
+	}
+	dst, err := cli.VolumeCreate(ctx, opts)
+	if err != nil {
+		return volumeapi.Volume{}, err
+	}
+	mounts := []mount.Mount{
+		VolumeMount(source, "/from", true),
+		VolumeMount(target, "/to", false),
+	}
+	if _, err := RunVolumeHelper(ctx, cli, mounts, []string{"cp", "-a", "/from/.", "/to/"}); err != nil {
+		_ = cli.VolumeRemove(context.Background(), target, true)
+		return volumeapi.Volume{}, err
+	}
+	return dst, nil
+}
+
+// CloneVolumeOptions returns the create options for a copy of src named
+// target. The anonymous label is dropped so a named copy is not pruned by
+// default. Local volumes backed by a device or bind mount are refused: the
+// copy would point at the same data.
+func CloneVolumeOptions(src volumeapi.Volume, target string) (volumeapi.CreateOptions, error) {
+	if src.Driver == "local" && src.Options["device"] != "" {
+		return volumeapi.CreateOptions{}, errdefs.InvalidParameter(fmt.Errorf("volume %s is backed by %s and cannot be copied", src.Name, src.Options["device"]))
+	}
+	var labels map[string]string
+	for k, v := range src.Labels {
+		if k == anonymousVolumeLabel {
+			continue
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[k] = v
+	}
+	return volumeapi.CreateOptions{
+		Name:       target,
+		Driver:     src.Driver,
+		DriverOpts: src.Options,
+		Labels:     labels,
+	}, nil
+}
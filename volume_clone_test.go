@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	volumeapi "github.com/docker/docker/api/types/volume"
+)
+
+func TestCloneVolumeOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     volumeapi.Volume
+		want    volumeapi.CreateOptions
+		wantErr bool
+	}{
+		{
+			name: "labels and options copied",
+			src:  volumeapi.Volume{Name: "data", Driver: "local", Options: map[string]string{"size": "1G"}, Labels: map[string]string{"env": "dev"}},
+			want: volumeapi.CreateOptions{Name: "copy", Driver: "local", DriverOpts: map[string]string{"size": "1G"}, Labels: map[string]string{"env": "dev"}},
+		},
+		{
+			name: "anonymous label dropped",
+			src:  volumeapi.Volume{Name: "0f3c", Driver: "local", Labels: map[string]string{anonymousVolumeLabel: "", "env": "dev"}},
+			want: volumeapi.CreateOptions{Name: "copy", Driver: "local", Labels: map[string]string{"env": "dev"}},
+		},
+		{
+			name: "only the anonymous label",
+			src:  volumeapi.Volume{Name: "0f3c", Driver: "local", Labels: map[string]string{anonymousVolumeLabel: ""}},
+			want: volumeapi.CreateOptions{Name: "copy", Driver: "local"},
+		},
+		{
+			name:    "bind mount",
+			src:     volumeapi.Volume{Name: "host", Driver: "local", Options: map[string]string{"type": "none", "o": "bind", "device": "/srv/data"}},
+			wantErr: true,
+		},
+		{
+			name:    "nfs device",
+			src:     volumeapi.Volume{Name: "nfs", Driver: "local", Options: map[string]string{"type": "nfs", "o": "addr=10.0.0.1", "device": ":/export"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CloneVolumeOptions(tt.src, "copy")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CloneVolumeOptions() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CloneVolumeOptions() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CloneVolumeOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultReadFileBytes = 1 << 20  // 1 MiB
+	maxReadFileBytes     = 10 << 20 // 10 MiB
+	maxVolumeUploadSize  = 1 << 30  // 1 GiB
+)
+
+// GET /go/volumes/{name}/file?path=/data/app.log&max_bytes=1048576
+// Returns the text content of a file. Binary files are reported with
+// binary=true and no content; use the download endpoint for those.
+func ReadVolumeFileHandler(w http.ResponseWriter, r *http.Request) {
+	volumeName := mux.Vars(r)["name"]
+	q := r.URL.Query()
+	if q.Get("path") == "" {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "path is required", Field: "path"})
+		return
+	}
+	p := path.Clean("/" + q.Get("path"))
+	maxBytes, err := strconv.ParseInt(q.Get("max_bytes"), 10, 64)
+	if err != nil || maxBytes <= 0 {
+		maxBytes = defaultReadFileBytes
+	}
+	if maxBytes > maxReadFileBytes {
+		maxBytes = maxReadFileBytes
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	// 헬퍼 컨테이너가 마운트하면 없는 볼륨이 새로 만들어지므로 먼저 확인
+	if _, err := cli.VolumeInspect(ctx, volumeName); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+
+	rc, stat, err := CopyFromVolume(ctx, cli, volumeName, p)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	defer rc.Close()
+
+	resp := map[string]any{
+		"path":     p,
+		"size":     stat.Size,
+		"mod_time": stat.Mtime,
+		"mode":     stat.Mode.String(),
+	}
+	if stat.Mode.IsDir() {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: "path is a directory", Field: "path"})
+		return
+	}
+	if stat.Mode&os.ModeSymlink != 0 {
+		resp["link_target"] = stat.LinkTarget
+		WriteJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	tr := tar.NewReader(rc)
+	if _, err := tr.Next(); err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	buf, err := io.ReadAll(io.LimitReader(tr, maxBytes+1))
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	truncated := int64(len(buf)) > maxBytes
+	if truncated {
+		buf = buf[:maxBytes]
+	}
+
+	binary := IsBinary(buf, truncated)
+	resp["truncated"] = truncated
+	resp["binary"] = binary
+	resp["content_type"] = http.DetectContentType(buf)
+	if !binary {
+		resp["content"] = string(buf)
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// IsBinary guesses whether data is not text: it contains NUL in the first
+// 8 KB or is not valid UTF-8. A rune cut off by truncation is tolerated.
+func IsBinary(data []byte, truncated bool) bool {
+	head := data
+	if len(head) > 8000 {
+		head = head[:8000]
+	}
+	if bytes.IndexByte(head, 0) >= 0 {
+		return true
+	}
+	if truncated {
+		for i := 0; i < utf8.UTFMax && len(data) > 0 && !utf8.Valid(data); i++ {
+			data = data[:len(data)-1]
+		}
+	}
+	return !utf8.Valid(data)
+}
+
+// GET /go/volumes/{name}/download?path=/&format=tar|zip|raw
+// Downloads a file or directory. raw sends a single regular file as-is.
+func DownloadVolumePathHandler(w http.ResponseWriter, r *http.Request) {
+	volumeName := mux.Vars(r)["name"]
+	q := r.URL.Query()
+	p := path.Clean("/" + q.Get("path"))
+	format, err := ArchiveFormat(q.Get("format"))
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	ClearDeadlines(w)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx := r.Context()
+	if _, err := cli.VolumeInspect(ctx, volumeName); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+
+	rc, stat, err := CopyFromVolume(ctx, cli, volumeName, p)
+	if err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+	defer rc.Close()
+
+	name := path.Base(p)
+	if p == "/" {
+		name = volumeName
+	}
+	WritePathDownload(w, rc, stat, name, format)
+}
+
+// POST /go/volumes/{name}/upload?path=/dir&uid=999&gid=999&name=app.conf
+// Accepts multipart files, a tar body or a single raw file; see CopyUpload.
+func UploadVolumeFilesHandler(w http.ResponseWriter, r *http.Request) {
+	volumeName := mux.Vars(r)["name"]
+	q := r.URL.Query()
+	dir := path.Clean("/" + q.Get("path"))
+	ClearDeadlines(w)
+	r.Body = http.MaxBytesReader(w, r.Body, maxVolumeUploadSize)
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	ctx := r.Context()
+	if _, err := cli.VolumeInspect(ctx, volumeName); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
+
+	files, err := CopyUpload(r, dir, func(dst string, content io.Reader) error {
+		return CopyToVolume(ctx, cli, volumeName, dst, content)
+	})
+	if err != nil {
+		WriteUploadError(w, err)
+		return
+	}
+	resp := map[string]any{"status": "uploaded", "name": volumeName, "path": dir}
+	if files != nil {
+		resp["files"] = files
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
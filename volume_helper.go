@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// 볼륨 작업용 헬퍼 컨테이너에 마운트되는 위치
+const volumeMountPoint = "/volume"
+
+// VolumeHelperImage is the image used for throwaway helper containers
+// (VOLUME_HELPER_IMAGE, default alpine:latest).
+func VolumeHelperImage() string {
+	if img := os.Getenv("VOLUME_HELPER_IMAGE"); img != "" {
+		return img
+	}
+	return "alpine:latest"
+}
+
+// VolumePath cleans a client supplied path and roots it at the helper's
+// mount point, so "../" cannot escape the volume.
+func VolumePath(p string) string {
+	return path.Join(volumeMountPoint, path.Clean("/"+p))
+}
+
+// VolumeMount mounts a named volume into a helper container.
+func VolumeMount(name, target string, readOnly bool) mount.Mount {
+	return mount.Mount{Type: mount.TypeVolume, Source: name, Target: target, ReadOnly: readOnly}
+}
+
+// CreateVolumeHelper creates (but does not start) a helper container with
+// mounts attached. A stopped container is enough for CopyFromContainer and
+// CopyToContainer. The returned cleanup removes it.
+func CreateVolumeHelper(ctx context.Context, cli *client.Client, mounts []mount.Mount, cmd []string) (string, func(), error) {
+	image, err := EnsureImage(ctx, cli, VolumeHelperImage(), "", nil)
+	if err != nil {
+		return "", nil, err
+	}
+	resp, err := cli.ContainerCreate(ctx,
+		&container.Config{Image: image, Cmd: cmd, Labels: map[string]string{"go-backend.helper": "volume"}},
+		&container.HostConfig{Mounts: mounts, NetworkMode: "none"},
+		nil, nil, "")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		// 요청 context가 이미 취소됐어도 헬퍼는 지워야 함
+		_ = cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+	}
+	return resp.ID, cleanup, nil
+}
+
+// RunVolumeHelper runs cmd in a helper container with mounts attached and
+// returns its stdout. A non-zero exit code is returned as a
+// *HelperExitError that carries stderr.
+func RunVolumeHelper(ctx context.Context, cli *client.Client, mounts []mount.Mount, cmd []string) ([]byte, error) {
+	id, cleanup, err := CreateVolumeHelper(ctx, cli, mounts, cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// 시작 전에 attach 해야 짧게 끝나는 명령의 출력을 놓치지 않음
+	attach, err := cli.ContainerAttach(ctx, id, container.AttachOptions{Stream: true, Stdout: true, Stderr: true})
+	if err != nil {
+		return nil, err
+	}
+	defer attach.Close()
+
+	if err := cli.ContainerStart(ctx, id, container.StartOptions{}); err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, attach.Reader); err != nil {
+		return nil, err
+	}
+
+	statusCh, errCh := cli.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		return nil, err
+	case st := <-statusCh:
+		if st.StatusCode != 0 {
+			return stdout.Bytes(), &HelperExitError{Code: st.StatusCode, Stderr: strings.TrimSpace(stderr.String())}
+		}
+	}
+	return stdout.Bytes(), nil
+}
+
+// HelperExitError is returned by RunVolumeHelper when the command exits
+// with a non-zero code.
+type HelperExitError struct {
+	Code   int64
+	Stderr string
+}
+
+func (e *HelperExitError) Error() string {
+	return fmt.Sprintf("helper exited with code %d: %s", e.Code, e.Stderr)
+}
+
+// CopyFromVolume returns a tar stream of p inside the named volume plus its
+// stat. Closing the stream also removes the helper container.
+func CopyFromVolume(ctx context.Context, cli *client.Client, volume, p string) (io.ReadCloser, container.PathStat, error) {
+	id, cleanup, err := CreateVolumeHelper(ctx, cli, []mount.Mount{VolumeMount(volume, volumeMountPoint, true)}, nil)
+	if err != nil {
+		return nil, container.PathStat{}, err
+	}
+	rc, stat, err := cli.CopyFromContainer(ctx, id, VolumePath(p))
+	if err != nil {
+		cleanup()
+		return nil, container.PathStat{}, VolumePathError(volume, p, err)
+	}
+	return &helperReadCloser{ReadCloser: rc, cleanup: cleanup}, stat, nil
+}
+
+// CopyToVolume extracts the tar stream content into dir of the named volume.
+func CopyToVolume(ctx context.Context, cli *client.Client, volume, dir string, content io.Reader) error {
+	id, cleanup, err := CreateVolumeHelper(ctx, cli, []mount.Mount{VolumeMount(volume, volumeMountPoint, false)}, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	err = cli.CopyToContainer(ctx, id, VolumePath(dir), content, container.CopyToContainerOptions{})
+	return VolumePathError(volume, dir, err)
+}
+
+// VolumePathError reports a path missing inside the volume as not found,
+// naming the volume path instead of the helper's mount point.
+func VolumePathError(volume, p string, err error) error {
+	if errdefs.IsNotFound(err) {
+		return errdefs.NotFound(fmt.Errorf("%s not found in volume %s", path.Clean("/"+p), volume))
+	}
+	return err
+}
+
+type helperReadCloser struct {
+	io.ReadCloser
+	cleanup func()
+}
+
+func (h *helperReadCloser) Close() error {
+	err := h.ReadCloser.Close()
+	h.cleanup()
+	return err
+}
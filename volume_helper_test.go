@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+)
+
+func TestVolumePathError(t *testing.T) {
+	missing := errdefs.NotFound(errors.New("Could not find the file /volume/logs/app.log in container 3f2a"))
+	err := VolumePathError("data", "logs/../logs/app.log", missing)
+	if !errdefs.IsNotFound(err) {
+		t.Fatalf("VolumePathError() = %v, want a not-found error", err)
+	}
+	if want := "/logs/app.log not found in volume data"; err.Error() != want {
+		t.Errorf("VolumePathError() = %q, want %q", err, want)
+	}
+	rec := httptest.NewRecorder()
+	WriteDockerError(rec, err)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("WriteDockerError() status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	other := errors.New("Cannot connect to the Docker daemon")
+	if got := VolumePathError("data", "/", other); got != other {
+		t.Errorf("VolumePathError() = %v, want the error unchanged", got)
+	}
+	if got := VolumePathError("data", "/", nil); got != nil {
+		t.Errorf("VolumePathError(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+)
+
+// 익명 볼륨에 도커가 붙이는 라벨 (기본 volume prune 대상)
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+// GET /go/volumes/usage?sort=size|name
+// Lists every volume with its size (from DiskUsage), the containers that
+// mount it and whether it is dangling. dangling_size is what a prune of all
+// unused volumes would reclaim.
+func VolumeUsageHandler(w http.ResponseWriter, r *http.Request) {
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer cli.Close()
+
+	// DiskUsage는 볼륨 크기를 계산하느라 오래 걸릴 수 있음
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	defer cancel()
+
+	vols, err := VolumeUsageReport(ctx, cli)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if r.URL.Query().Get("sort") == "name" {
+		sort.Slice(vols, func(i, j int) bool { return vols[i].Name < vols[j].Name })
+	}
+
+	var total, dangling int64
+	danglingCount := 0
+	for _, v := range vols {
+		if v.Size > 0 {
+			total += v.Size
+		}
+		if v.Dangling {
+			danglingCount++
+			if v.Size > 0 {
+				dangling += v.Size
+			}
+		}
+	}
+	WriteJSON(w, http.StatusOK, map[string]any{
+		"volumes":             vols,
+		"count":               len(vols),
+		"total_size":          total,
+		"total_size_human":    units.HumanSize(float64(total)),
+		"dangling_count":      danglingCount,
+		"dangling_size":       dangling,
+		"dangling_size_human": units.HumanSize(float64(dangling)),
+	})
+}
+
+// VolumeUsageReport joins DiskUsage volume data with the mounts of all
+// containers. The result is sorted by size, largest first. Size is -1 when
+// the driver cannot report it.
+func VolumeUsageReport(ctx context.Context, cli *client.Client) ([]VolumeUsage, error) {
+	du, err := cli.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, err
+	}
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	consumers := map[string][]VolumeConsumer{}
+	for _, c := range containers {
+		for _, m := range c.Mounts {
+			if m.Type != mount.TypeVolume {
+				continue
+			}
+			consumers[m.Name] = append(consumers[m.Name], VolumeConsumer{
+				ID:          c.ID[:12],
+				Name:        ContainerName(c),
+				State:       c.State,
+				Destination: m.Destination,
+				ReadOnly:    !m.RW,
+			})
+		}
+	}
+
+	vols := make([]VolumeUsage, 0, len(du.Volumes))
+	for _, v := range du.Volumes {
+		u := VolumeUsage{
+			Name:       v.Name,
+			Driver:     v.Driver,
+			Mountpoint: v.Mountpoint,
+			CreatedAt:  v.CreatedAt,
+			Labels:     v.Labels,
+			Size:       -1,
+			RefCount:   -1,
+			Consumers:  consumers[v.Name],
+		}
+		if v.UsageData != nil {
+			u.Size = v.UsageData.Size
+			u.RefCount = v.UsageData.RefCount
+		}
+		if u.Size >= 0 {
+			u.SizeHuman = units.HumanSize(float64(u.Size))
+		}
+		if u.Consumers == nil {
+			u.Consumers = []VolumeConsumer{}
+		}
+		_, u.Anonymous = v.Labels[anonymousVolumeLabel]
+		u.Dangling = len(u.Consumers) == 0 && u.RefCount <= 0
+		vols = append(vols, u)
+	}
+	sort.SliceStable(vols, func(i, j int) bool { return vols[i].Size > vols[j].Size })
+	return vols, nil
+}
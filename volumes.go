@@ -3,15 +3,18 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"os/exec"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/errdefs"
 	"github.com/gorilla/mux"
 	volumeapi "github.com/docker/docker/api/types/volume"
 
@@ -101,7 +104,18 @@ WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 WriteJSON(w, http.StatusOK, map[string]string{"status": "deleted", "name": name})
 }
 
+// POST /go/volumes/prune?dry_run=true&all=true&label=env=dev
+// Without all=true only anonymous volumes are removed. The daemon has no
+// until filter for volumes.
 func PruneVolumesHandler(w http.ResponseWriter, r *http.Request) {
+	args, err := PruneFilters(r, false)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	if r.URL.Query().Get("all") == "true" {
+		args.Add("all", "true")
+	}
 	cli, err := NewDockerClient()
 	if err != nil {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
@@ -109,10 +123,20 @@ WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 	}
 	defer cli.Close()
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
 	defer cancel()
 
-	report, err := cli.VolumesPrune(ctx, filters.Args{})
+	if r.URL.Query().Get("dry_run") == "true" {
+		preview, err := PreviewVolumePrune(ctx, cli, args)
+		if err != nil {
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		WriteJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	report, err := cli.VolumesPrune(ctx, args)
 	if err != nil {
 WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
@@ -121,104 +145,172 @@ WriteJSON(w, http.StatusOK, report)
 }
 
 // Volume file system browsing
+// GET /go/volumes/{name}/browse?path=/&offset=0&limit=500
+// Lists one directory through a helper container that prints stat records,
+// so names with spaces, symlink targets, owners and mtimes come back exact.
 func BrowseVolumeHandler(w http.ResponseWriter, r *http.Request) {
 	volumeName := mux.Vars(r)["name"]
-	path := r.URL.Query().Get("path")
-	if path == "" {
-		path = "/"
+	q := r.URL.Query()
+	dir := path.Clean("/" + q.Get("path"))
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 500
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	cli, err := NewDockerClient()
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
 	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
 
-	log.Printf("Browsing volume %s at path %s", volumeName, path)
+	if _, err := cli.VolumeInspect(ctx, volumeName); err != nil {
+		WriteDockerError(w, err)
+		return
+	}
 
-	// Use docker CLI directly for simplicity
-	cmd := exec.Command("docker", "run", "--rm", "-v", fmt.Sprintf("%s:/volume", volumeName), "alpine:latest", "ls", "-la", fmt.Sprintf("/volume%s", path))
-	
-	output, err := cmd.CombinedOutput()
+	out, err := RunVolumeHelper(ctx, cli,
+		[]mount.Mount{VolumeMount(volumeName, volumeMountPoint, true)},
+		[]string{"sh", "-c", listDirScript, "sh", VolumePath(dir)})
 	if err != nil {
-		log.Printf("Docker command failed: %v, output: %s", err, string(output))
-WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to browse volume: %v", err)})
+		log.Printf("Browsing volume %s at %s failed: %v", volumeName, dir, err)
+		WriteDockerError(w, BrowseError(dir, err))
 		return
 	}
 
-	log.Printf("Docker output: %s", string(output))
+	files := ParseStatRecords(out, dir)
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].IsDir != files[j].IsDir {
+			return files[i].IsDir
+		}
+		return files[i].Name < files[j].Name
+	})
+	total := len(files)
+	end := offset + limit
+	if offset > total {
+		offset = total
+	}
+	if end > total {
+		end = total
+	}
 
-	// Parse ls output
-	files := ParseLsOutput(string(output), path)
-	log.Printf("Parsed %d files", len(files))
-	
-WriteJSON(w, http.StatusOK, map[string]interface{}{
-		"path":  path,
-		"files": files,
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"path":   dir,
+		"files":  files[offset:end],
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
 	})
 }
 
-func ParseLsOutput(output, currentPath string) []VolumeFileInfo {
-	log.Printf("Parsing ls output: %s", output)
-	
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	var files []VolumeFileInfo
-	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.Contains(line, "total") {
-			continue
-		}
-		
-		// Parse ls -la output format
-		parts := strings.Fields(line)
-		if len(parts) < 9 {
-			log.Printf("Skipping line with insufficient parts: %s", line)
-			continue
+// listDirScript prints, for each entry of directory $1, a stat line followed
+// by NUL-separated name and symlink target. NUL is the only byte that cannot
+// appear in a file name, so the output parses unambiguously. It exits 2 when
+// $1 does not exist and 3 when it is not a directory.
+const listDirScript = `[ -e "$1" ] || exit 2
+[ -d "$1" ] || exit 3
+cd -- "$1" || exit 4
+find . -mindepth 1 -maxdepth 1 -exec sh -c 'for f; do stat -c "%f %s %Y %u %g %U %G" -- "$f" && printf "\0%s\0%s\0" "${f#./}" "$(readlink -- "$f")"; done' sh {} +
+true`
+
+// BrowseError maps listDirScript's exit codes onto errdefs so a missing
+// path is 404 and a file is 400.
+func BrowseError(dir string, err error) error {
+	var exit *HelperExitError
+	if errors.As(err, &exit) {
+		switch exit.Code {
+		case 2:
+			return errdefs.NotFound(fmt.Errorf("%s not found", dir))
+		case 3:
+			return errdefs.InvalidParameter(fmt.Errorf("%s is not a directory", dir))
 		}
-		
-		permissions := parts[0]
-		sizeStr := parts[4]
-		modTimeStr := strings.Join(parts[5:8], " ")
-		name := strings.Join(parts[8:], " ")
-		
-		// Skip . and .. entries
-		if name == "." || name == ".." {
+	}
+	return fmt.Errorf("Failed to browse volume: %w", err)
+}
+
+// ParseStatRecords decodes listDirScript output for the entries of dir.
+func ParseStatRecords(out []byte, dir string) []VolumeFileInfo {
+	tokens := strings.Split(string(out), "\x00")
+	files := []VolumeFileInfo{}
+	for i := 0; i+2 < len(tokens); i += 3 {
+		fields := strings.Fields(tokens[i])
+		name := tokens[i+1]
+		if len(fields) < 7 || name == "" {
 			continue
 		}
-		
-		size, err := strconv.ParseInt(sizeStr, 10, 64)
+		rawMode, err := strconv.ParseUint(fields[0], 16, 32)
 		if err != nil {
-			log.Printf("Failed to parse size %s: %v", sizeStr, err)
-			size = 0
-		}
-		
-		isDir := strings.HasPrefix(permissions, "d")
-		
-		// Parse modification time
-		modTime, err := time.Parse("Jan 2 15:04", modTimeStr)
-		if err != nil {
-			log.Printf("Failed to parse time %s: %v", modTimeStr, err)
-			modTime = time.Now()
-		} else {
-			modTime = modTime.AddDate(time.Now().Year(), 0, 0) // Add current year
-		}
-		
-		filePath := currentPath
-		if filePath == "/" {
-			filePath = "/" + name
-		} else {
-			filePath = filePath + "/" + name
+			continue
 		}
-		
-		fileInfo := VolumeFileInfo{
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		mtime, _ := strconv.ParseInt(fields[2], 10, 64)
+		uid, _ := strconv.Atoi(fields[3])
+		gid, _ := strconv.Atoi(fields[4])
+		fileType, perms := UnixModeString(uint32(rawMode))
+		files = append(files, VolumeFileInfo{
 			Name:        name,
-			Path:        filePath,
-			IsDir:       isDir,
+			Path:        path.Join(dir, name),
+			IsDir:       fileType == "dir",
 			Size:        size,
-			Mode:        permissions,
-			ModTime:     modTime,
-			Permissions: permissions,
-		}
-		
-		log.Printf("Parsed file: %+v", fileInfo)
-		files = append(files, fileInfo)
+			Mode:        perms,
+			ModTime:     time.Unix(mtime, 0).UTC(),
+			Permissions: perms,
+			Type:        fileType,
+			LinkTarget:  tokens[i+2],
+			UID:         uid,
+			GID:         gid,
+			Owner:       fields[5],
+			Group:       fields[6],
+		})
 	}
-	
-	log.Printf("Total parsed files: %d", len(files))
 	return files
 }
+
+// UnixModeString returns the file type name and the ls-style permission
+// string (e.g. "drwxr-xr-x") of a raw st_mode.
+func UnixModeString(m uint32) (string, string) {
+	fileType, c := "file", byte('-')
+	switch m & 0o170000 {
+	case 0o040000:
+		fileType, c = "dir", 'd'
+	case 0o120000:
+		fileType, c = "symlink", 'l'
+	case 0o020000:
+		fileType, c = "char", 'c'
+	case 0o060000:
+		fileType, c = "block", 'b'
+	case 0o010000:
+		fileType, c = "fifo", 'p'
+	case 0o140000:
+		fileType, c = "socket", 's'
+	}
+	b := []byte{c}
+	const rwx = "rwxrwxrwx"
+	for i := 0; i < 9; i++ {
+		if m&(1<<uint(8-i)) != 0 {
+			b = append(b, rwx[i])
+		} else {
+			b = append(b, '-')
+		}
+	}
+	special := func(bit uint32, idx int, set, unset byte) {
+		if m&bit != 0 {
+			if b[idx] == '-' {
+				b[idx] = unset
+			} else {
+				b[idx] = set
+			}
+		}
+	}
+	special(0o4000, 3, 's', 'S')
+	special(0o2000, 6, 's', 'S')
+	special(0o1000, 9, 't', 'T')
+	return fileType, string(b)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+func TestUnixModeString(t *testing.T) {
+	tests := []struct {
+		mode     uint32
+		fileType string
+		perms    string
+	}{
+		{0o100644, "file", "-rw-r--r--"},
+		{0o040755, "dir", "drwxr-xr-x"},
+		{0o120777, "symlink", "lrwxrwxrwx"},
+		{0o104755, "file", "-rwsr-xr-x"},
+		{0o104644, "file", "-rwSr--r--"},
+		{0o102755, "file", "-rwxr-sr-x"},
+		{0o041777, "dir", "drwxrwxrwt"},
+		{0o041776, "dir", "drwxrwxrwT"},
+		{0o020620, "char", "crw--w----"},
+		{0o060660, "block", "brw-rw----"},
+		{0o010644, "fifo", "prw-r--r--"},
+		{0o140755, "socket", "srwxr-xr-x"},
+	}
+	for _, tt := range tests {
+		fileType, perms := UnixModeString(tt.mode)
+		if fileType != tt.fileType || perms != tt.perms {
+			t.Errorf("UnixModeString(%o) = %q, %q; want %q, %q", tt.mode, fileType, perms, tt.fileType, tt.perms)
+		}
+	}
+}
+
+func TestParseStatRecords(t *testing.T) {
+	// stat -c "%f %s %Y %u %g %U %G" output followed by \0name\0link\0, as
+	// produced by the find command in BrowseVolumeHandler
+	out := "81a4 12 1700000000 0 0 root root\n\x00my file.txt\x00\x00" +
+		"41ed 4096 1700000001 1000 1000 app app\n\x00line\nbreak\x00\x00" +
+		"a1ff 7 1700000002 0 0 root root\n\x00current\x00releases/v2\x00" +
+		"89ed 10 1700000003 0 0 root root\n\x00sudo-like\x00\x00" +
+		"garbage\n\x00skipped\x00\x00" +
+		"81a4 1 1 0 0 root"
+
+	files := ParseStatRecords([]byte(out), "/data")
+	want := []VolumeFileInfo{
+		{Name: "my file.txt", Path: "/data/my file.txt", Size: 12, Mode: "-rw-r--r--", Permissions: "-rw-r--r--", Type: "file",
+			ModTime: time.Unix(1700000000, 0).UTC(), Owner: "root", Group: "root"},
+		{Name: "line\nbreak", Path: "/data/line\nbreak", IsDir: true, Size: 4096, Mode: "drwxr-xr-x", Permissions: "drwxr-xr-x", Type: "dir",
+			ModTime: time.Unix(1700000001, 0).UTC(), UID: 1000, GID: 1000, Owner: "app", Group: "app"},
+		{Name: "current", Path: "/data/current", Size: 7, Mode: "lrwxrwxrwx", Permissions: "lrwxrwxrwx", Type: "symlink",
+			ModTime: time.Unix(1700000002, 0).UTC(), LinkTarget: "releases/v2", Owner: "root", Group: "root"},
+		{Name: "sudo-like", Path: "/data/sudo-like", Size: 10, Mode: "-rwsr-xr-x", Permissions: "-rwsr-xr-x", Type: "file",
+			ModTime: time.Unix(1700000003, 0).UTC(), Owner: "root", Group: "root"},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("got %d records, want %d: %+v", len(files), len(want), files)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("record %d = %+v, want %+v", i, files[i], want[i])
+		}
+	}
+}
+
+func TestParseStatRecordsEmpty(t *testing.T) {
+	if files := ParseStatRecords(nil, "/"); files == nil || len(files) != 0 {
+		t.Errorf("ParseStatRecords(nil) = %#v, want an empty slice", files)
+	}
+}
+
+func TestBrowseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		notFound bool
+		invalid  bool
+	}{
+		{"missing path", &HelperExitError{Code: 2}, true, false},
+		{"not a directory", &HelperExitError{Code: 3}, false, true},
+		{"cd failed", &HelperExitError{Code: 4, Stderr: "Permission denied"}, false, false},
+		{"daemon error", context.DeadlineExceeded, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := BrowseError("/data", tt.err)
+			if got := errdefs.IsNotFound(err); got != tt.notFound {
+				t.Errorf("IsNotFound(%v) = %v, want %v", err, got, tt.notFound)
+			}
+			if got := errdefs.IsInvalidParameter(err); got != tt.invalid {
+				t.Errorf("IsInvalidParameter(%v) = %v, want %v", err, got, tt.invalid)
+			}
+			if !tt.notFound && !tt.invalid && !errors.Is(err, tt.err) {
+				t.Errorf("BrowseError() = %v, want it to wrap %v", err, tt.err)
+			}
+		})
+	}
+}